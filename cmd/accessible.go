@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// accessible prints plain labelled lines instead of running the TUI (--accessible)
+var accessible bool
+
+// stripIcons removes the Nerd Font glyphs and emoji from a description for screen readers
+func stripIcons(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if isIcon(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// printAccessible writes the users' events as line-oriented plain text with explicit
+// labels (no alt screen, colors or icons) so they can be followed with a screen reader
+func printAccessible(w io.Writer, users []User, fetch fetchOptions, opts viewOptions) error {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	for _, user := range users {
+		client, err := newClient(user.Token, user.Host)
+		if err != nil {
+			return err
+		}
+		fetch.Host, fetch.Token = user.Host, user.Token
+		feed, err := fetchEvents(context.Background(), githubAPI{client}, user.Name, fetch)
+		if err != nil {
+			title, hint := describeFetchError(err, user.Name)
+			if hint != "" {
+				title += ". " + hint
+			}
+			fmt.Fprintf(w, "Error for user %s: %s\n", user.Name, title)
+			continue
+		}
+		m := initialModel(user, fetch, opts)
+		fmt.Fprintf(w, "Events for user %s: %d events.\n", user.Name, len(feed.Items))
+		if !feed.CachedAt.IsZero() {
+			fmt.Fprintf(w, "Offline: showing events cached %s.\n", humanTime(feed.CachedAt))
+		}
+		for _, warning := range feed.Warnings {
+			fmt.Fprintf(w, "Warning: %s.\n", warning)
+		}
+		for i, item := range feed.Items {
+			fmt.Fprintf(w, "Event %d of %d. Type: %s. Repository: %s. Actor: %s. Time: %s. Description: %s\n",
+				i+1, len(feed.Items), strings.TrimSuffix(item.Type, "Event"), item.Repository.Name,
+				item.Actor.Login, m.formatDate(item.CreatedAt), stripIcons(item.Description))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
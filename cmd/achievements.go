@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v66/github"
+)
+
+// maxAchievementRepos is how many repositories have their language looked up for
+// the Polyglot badge with --enrich
+const maxAchievementRepos = 30
+
+// achievement is a fun badge earned from the user's events
+type achievement struct {
+	icon, name string
+	color      lipgloss.Color
+	detail     string // e.g. "40% of pushes after midnight"
+	need       string // what it takes when not earned yet, e.g. "25% needed"
+	earned     bool
+}
+
+// achievementStats are the counts the achievements are computed from
+type achievementStats struct {
+	events, pushes, lateNight, earlyMorning, weekend int
+	reviews, releases, issues, comments, stars       int
+	repos, languages                                 map[string]bool
+}
+
+func (m model) achievementStats() achievementStats {
+	s := achievementStats{repos: make(map[string]bool), languages: make(map[string]bool)}
+	for _, item := range m.events {
+		if item.Actor == nil || !strings.EqualFold(item.Actor.Login, m.username) {
+			continue
+		}
+		s.events++
+		at := item.CreatedAt.Local()
+		if wd := at.Weekday(); wd == 0 || wd == 6 {
+			s.weekend++
+		}
+		if item.Repository != nil {
+			s.repos[item.Repository.Name] = true
+			if cached, ok := repoContextCache.Load(item.Repository.Name); ok && cached.(*repoContext).Language != "" {
+				s.languages[cached.(*repoContext).Language] = true
+			}
+		}
+		switch item.Type {
+		case "PushEvent":
+			s.pushes++
+			switch hour := at.Hour(); {
+			case hour < 5:
+				s.lateNight++
+			case hour < 8:
+				s.earlyMorning++
+			}
+		case "PullRequestReviewEvent":
+			s.reviews++
+		case "ReleaseEvent":
+			s.releases++
+		case "IssuesEvent":
+			if item.Event == nil {
+				break
+			}
+			if p, err := item.Event.ParsePayload(); err == nil {
+				if issue, ok := p.(*github.IssuesEvent); ok && issue.GetAction() == "opened" {
+					s.issues++
+				}
+			}
+		case "IssueCommentEvent", "PullRequestReviewCommentEvent", "CommitCommentEvent":
+			s.comments++
+		case "WatchEvent":
+			s.stars++
+		}
+	}
+	return s
+}
+
+// achievements computes the badges of the loaded events, earned or not
+func (m model) achievements() []achievement {
+	s := m.achievementStats()
+	share := func(n, of int) int {
+		if of == 0 {
+			return 0
+		}
+		return n * 100 / of
+	}
+	count := func(icon, name, color string, n, goal int, what string) achievement {
+		return achievement{icon: icon, name: name, color: lipgloss.Color(color),
+			detail: fmt.Sprintf("%d %s", n, what), need: fmt.Sprintf("%d needed", goal), earned: n >= goal}
+	}
+	percent := func(icon, name, color string, n, of, goal int, what string) achievement {
+		return achievement{icon: icon, name: name, color: lipgloss.Color(color),
+			detail: fmt.Sprintf("%d%% of %s", share(n, of), what), need: fmt.Sprintf("%d%% needed", goal),
+			earned: of >= 5 && share(n, of) >= goal}
+	}
+	polyglot := count("🌐", "Polyglot", "#8250df", len(s.languages), 5, "languages")
+	if !m.fetch.Enrich {
+		polyglot.need = "needs --enrich"
+	}
+	return []achievement{
+		percent("🦉", "Night Owl", "#1f6feb", s.lateNight, s.pushes, 25, "pushes after midnight"),
+		percent("🐦", "Early Bird", "#bf8700", s.earlyMorning, s.pushes, 25, "pushes before 8am"),
+		percent("🏄", "Weekend Warrior", "#cf222e", s.weekend, s.events, 30, "events on weekends"),
+		polyglot,
+		count("🔥", "On Fire", "#fb8500", m.streak.Days, 7, "day streak"),
+		count("🔍", "Reviewer", "#1a7f37", s.reviews, 10, "pull request reviews"),
+		count("🚀", "Shipper", "#0969da", s.releases, 3, "releases"),
+		count("🐛", "Bug Hunter", "#9a6700", s.issues, 5, "issues opened"),
+		count("💬", "Chatterbox", "#6639ba", s.comments, 20, "comments"),
+		count("🧭", "Explorer", "#116329", len(s.repos), 10, "repositories"),
+		count("⭐", "Stargazer", "#d4a72c", s.stars, 10, "repositories starred"),
+	}
+}
+
+// openAchievements shows the achievements overlay, looking up the languages of the
+// repositories for Polyglot with --enrich
+func (m *model) openAchievements() tea.Cmd {
+	m.showAchievements = true
+	if !m.fetch.Enrich {
+		return nil
+	}
+	var cmds []tea.Cmd
+	seen := make(map[string]bool)
+	for _, item := range m.events {
+		if item.Repository == nil || seen[item.Repository.Name] || len(seen) == maxAchievementRepos {
+			continue
+		}
+		seen[item.Repository.Name] = true
+		cmds = append(cmds, fetchRepoContextCmd(m.api(), item.Repository.Name))
+	}
+	return tea.Batch(cmds...)
+}
+
+// achievementsView renders the earned badges followed by the ones still locked
+func (m model) achievementsView() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.Accent)
+	dim := lipgloss.NewStyle().Foreground(currentTheme.Dim)
+	badge := func(a achievement) string {
+		name := a.name
+		if !asciiConsole {
+			name = a.icon + " " + name
+		}
+		return lipgloss.NewStyle().Bold(true).Padding(0, 1).Foreground(lipgloss.Color("#ffffff")).Background(a.color).Render(name)
+	}
+	all := m.achievements()
+	width := 0
+	for _, a := range all {
+		width = max(width, lipgloss.Width(badge(a)))
+	}
+
+	var earned, locked []string
+	for _, a := range all {
+		if a.earned {
+			b := badge(a)
+			earned = append(earned, b+strings.Repeat(" ", width-lipgloss.Width(b))+"  "+a.detail)
+		} else {
+			locked = append(locked, dim.Render(fmt.Sprintf("%-*s  %s (%s)", width, "  "+a.name, a.detail, a.need)))
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(title.Render(fmt.Sprintf("Achievements of %s", m.username)) + "\n\n")
+	if len(earned) == 0 {
+		sb.WriteString("None yet, keep going!\n")
+	}
+	for _, line := range earned {
+		sb.WriteString(line + "\n")
+	}
+	if len(locked) > 0 {
+		sb.WriteString("\n" + title.Render("Locked") + "\n\n")
+		sb.WriteString(strings.Join(locked, "\n"))
+	}
+	return lipgloss.NewStyle().Padding(1, 2).Render(strings.TrimSuffix(sb.String(), "\n")) + "\n\n  press any key to close\n"
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var confirmStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("204")).
+	Padding(0, 1)
+
+// confirmMsg asks the user to confirm an action before running it
+type confirmMsg struct {
+	username string
+	received bool // for the received events tab of --me
+	prompt   string
+	action   func() (string, error)
+}
+
+// actionDoneMsg reports the outcome of a confirmed action
+type actionDoneMsg struct {
+	username string
+	received bool
+	status   string
+	err      error
+}
+
+// toggleStarCmd checks whether the selected repository is starred and asks to (un)star it
+func (m model) toggleStarCmd() tea.Cmd {
+	item, ok := m.selectedEvent()
+	if !ok {
+		return nil
+	}
+	api, username, received := m.api(), m.username, m.fetch.Received
+	repo := item.Repository.Name
+	return func() tea.Msg {
+		owner, name, ok := strings.Cut(repo, "/")
+		if !ok {
+			return actionDoneMsg{username: username, received: received, err: fmt.Errorf("invalid repository name: %s", repo)}
+		}
+		ctx := context.Background()
+		starred, _, err := api.IsStarred(ctx, owner, name)
+		if err != nil {
+			return actionDoneMsg{username: username, received: received, err: fmt.Errorf("failed to check star on %s: %v", repo, err)}
+		}
+		if starred {
+			return confirmMsg{
+				username: username,
+				received: received,
+				prompt:   fmt.Sprintf("Unstar %s?", repo),
+				action: func() (string, error) {
+					if _, err := api.Unstar(ctx, owner, name); err != nil {
+						return "", fmt.Errorf("failed to unstar %s: %v", repo, err)
+					}
+					return fmt.Sprintf("unstarred %s", repo), nil
+				},
+			}
+		}
+		return confirmMsg{
+			username: username,
+			received: received,
+			prompt:   fmt.Sprintf("Star %s?", repo),
+			action: func() (string, error) {
+				if _, err := api.Star(ctx, owner, name); err != nil {
+					return "", fmt.Errorf("failed to star %s: %v", repo, err)
+				}
+				return fmt.Sprintf("starred %s", repo), nil
+			},
+		}
+	}
+}
+
+// toggleFollowCmd checks whether the viewed user is followed and asks to (un)follow them
+func (m model) toggleFollowCmd() tea.Cmd {
+	api, user, received := m.api(), m.username, m.fetch.Received
+	return func() tea.Msg {
+		ctx := context.Background()
+		following, _, err := api.IsFollowing(ctx, "", user)
+		if err != nil {
+			return actionDoneMsg{username: user, received: received, err: fmt.Errorf("failed to check follow on %s: %v", user, err)}
+		}
+		if following {
+			return confirmMsg{
+				username: user,
+				received: received,
+				prompt:   fmt.Sprintf("Unfollow %s?", user),
+				action: func() (string, error) {
+					if _, err := api.Unfollow(ctx, user); err != nil {
+						return "", fmt.Errorf("failed to unfollow %s: %v", user, err)
+					}
+					return fmt.Sprintf("unfollowed %s", user), nil
+				},
+			}
+		}
+		return confirmMsg{
+			username: user,
+			received: received,
+			prompt:   fmt.Sprintf("Follow %s?", user),
+			action: func() (string, error) {
+				if _, err := api.Follow(ctx, user); err != nil {
+					return "", fmt.Errorf("failed to follow %s: %v", user, err)
+				}
+				return fmt.Sprintf("followed %s", user), nil
+			},
+		}
+	}
+}
+
+func (m model) updateConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "y", "Y":
+			action, username, received := m.confirm.action, m.username, m.fetch.Received
+			m.confirm = nil
+			return m, func() tea.Msg {
+				status, err := action()
+				return actionDoneMsg{username: username, received: received, status: status, err: err}
+			}
+		case "ctrl+c":
+			return m, tea.Quit
+		default:
+			m.confirm = nil
+			m.status = "cancelled"
+		}
+	}
+	return m, nil
+}
+
+func (m model) confirmView() string {
+	return confirmStyle.Render(m.confirm.prompt + " (y/N)")
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// eventsAPI is the part of the GitHub API the events view uses (see fetchEvents),
+// so the event pipeline and the TUI can run against a stub instead of the real API
+type eventsAPI interface {
+	GetUser(ctx context.Context, login string) (*github.User, *github.Response, error)
+	SearchUsers(ctx context.Context, query string, opts *github.SearchOptions) (*github.UsersSearchResult, *github.Response, error)
+	ListEventsPerformedByUser(ctx context.Context, username string, publicOnly bool, opts *github.ListOptions) ([]*github.Event, *github.Response, error)
+	ListEventsReceivedByUser(ctx context.Context, username string, publicOnly bool, opts *github.ListOptions) ([]*github.Event, *github.Response, error)
+	GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error)
+	CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error)
+
+	// Used by the overlays and actions of the events view
+	GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	SearchIssues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error)
+	ListRepositoryEvents(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Event, *github.Response, error)
+	GetLatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error)
+	GetRelease(ctx context.Context, owner, repo string, id int64) (*github.RepositoryRelease, *github.Response, error)
+	IsStarred(ctx context.Context, owner, repo string) (bool, *github.Response, error)
+	Star(ctx context.Context, owner, repo string) (*github.Response, error)
+	Unstar(ctx context.Context, owner, repo string) (*github.Response, error)
+	IsFollowing(ctx context.Context, user, target string) (bool, *github.Response, error)
+	Follow(ctx context.Context, user string) (*github.Response, error)
+	Unfollow(ctx context.Context, user string) (*github.Response, error)
+}
+
+// githubAPI is the eventsAPI of a go-github client
+type githubAPI struct {
+	client *github.Client
+}
+
+func (a githubAPI) GetUser(ctx context.Context, login string) (*github.User, *github.Response, error) {
+	return a.client.Users.Get(ctx, login)
+}
+
+func (a githubAPI) SearchUsers(ctx context.Context, query string, opts *github.SearchOptions) (*github.UsersSearchResult, *github.Response, error) {
+	return a.client.Search.Users(ctx, query, opts)
+}
+
+func (a githubAPI) ListEventsPerformedByUser(ctx context.Context, username string, publicOnly bool, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	return a.client.Activity.ListEventsPerformedByUser(ctx, username, publicOnly, opts)
+}
+
+func (a githubAPI) ListEventsReceivedByUser(ctx context.Context, username string, publicOnly bool, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	return a.client.Activity.ListEventsReceivedByUser(ctx, username, publicOnly, opts)
+}
+
+func (a githubAPI) GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	return a.client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
+}
+
+func (a githubAPI) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	return a.client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+}
+
+func (a githubAPI) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return a.client.PullRequests.Get(ctx, owner, repo, number)
+}
+
+func (a githubAPI) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	return a.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+}
+
+func (a githubAPI) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	return a.client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+}
+
+func (a githubAPI) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return a.client.Repositories.Get(ctx, owner, repo)
+}
+
+func (a githubAPI) SearchIssues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error) {
+	return a.client.Search.Issues(ctx, query, opts)
+}
+
+func (a githubAPI) ListRepositoryEvents(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	return a.client.Activity.ListRepositoryEvents(ctx, owner, repo, opts)
+}
+
+func (a githubAPI) GetLatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error) {
+	return a.client.Repositories.GetLatestRelease(ctx, owner, repo)
+}
+
+func (a githubAPI) GetRelease(ctx context.Context, owner, repo string, id int64) (*github.RepositoryRelease, *github.Response, error) {
+	return a.client.Repositories.GetRelease(ctx, owner, repo, id)
+}
+
+func (a githubAPI) IsStarred(ctx context.Context, owner, repo string) (bool, *github.Response, error) {
+	return a.client.Activity.IsStarred(ctx, owner, repo)
+}
+
+func (a githubAPI) Star(ctx context.Context, owner, repo string) (*github.Response, error) {
+	return a.client.Activity.Star(ctx, owner, repo)
+}
+
+func (a githubAPI) Unstar(ctx context.Context, owner, repo string) (*github.Response, error) {
+	return a.client.Activity.Unstar(ctx, owner, repo)
+}
+
+func (a githubAPI) IsFollowing(ctx context.Context, user, target string) (bool, *github.Response, error) {
+	return a.client.Users.IsFollowing(ctx, user, target)
+}
+
+func (a githubAPI) Follow(ctx context.Context, user string) (*github.Response, error) {
+	return a.client.Users.Follow(ctx, user)
+}
+
+func (a githubAPI) Unfollow(ctx context.Context, user string) (*github.Response, error) {
+	return a.client.Users.Unfollow(ctx, user)
+}
@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// errNotStubbed is returned by the stubAPI calls it has no data for
+var errNotStubbed = errors.New("not stubbed")
+
+// stubAPI is an in-memory eventsAPI serving canned users and events (newest first),
+// paged like the real API, and repositories. Diff stats, CI status, releases, user
+// search and following aren't stubbed, and (un)starring doesn't change Starred.
+type stubAPI struct {
+	Viewer   string // login of the token's user
+	Users    map[string]*github.User
+	Events   map[string][]*github.Event
+	Received map[string][]*github.Event    // events of the users and repos each user follows
+	ETag     string                        // of the first page, which is 304 Not Modified when requested with it
+	Status   int                           // when set, listing events fails with this status code
+	Repos    map[string]*github.Repository // by full name
+	Starred  map[string]bool               // full names of the repos the viewer starred
+}
+
+// stubResponse is a successful response with the pagination of page
+func stubResponse(status, page, lastPage int) *github.Response {
+	resp := &github.Response{Response: &http.Response{StatusCode: status, Header: make(http.Header)}}
+	if page < lastPage {
+		resp.NextPage = page + 1
+	}
+	return resp
+}
+
+// stubError is an API error response with the status code
+func stubError(status int) (*github.Response, error) {
+	resp := stubResponse(status, 0, 0)
+	return resp, &github.ErrorResponse{Response: resp.Response, Message: http.StatusText(status)}
+}
+
+func (a stubAPI) GetUser(ctx context.Context, login string) (*github.User, *github.Response, error) {
+	if login == "" {
+		login = a.Viewer
+	}
+	user, ok := a.Users[login]
+	if !ok {
+		resp, err := stubError(http.StatusNotFound)
+		return nil, resp, err
+	}
+	return user, stubResponse(http.StatusOK, 0, 0), nil
+}
+
+func (a stubAPI) SearchUsers(ctx context.Context, query string, opts *github.SearchOptions) (*github.UsersSearchResult, *github.Response, error) {
+	return &github.UsersSearchResult{}, stubResponse(http.StatusOK, 0, 0), nil
+}
+
+func (a stubAPI) ListEventsPerformedByUser(ctx context.Context, username string, publicOnly bool, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	return a.listEvents(ctx, a.Events, username, opts)
+}
+
+func (a stubAPI) ListEventsReceivedByUser(ctx context.Context, username string, publicOnly bool, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	return a.listEvents(ctx, a.Received, username, opts)
+}
+
+// listEvents serves a page of the user's events in feeds
+func (a stubAPI) listEvents(ctx context.Context, feeds map[string][]*github.Event, username string, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	if _, ok := a.Users[username]; !ok {
+		resp, err := stubError(http.StatusNotFound)
+		return nil, resp, err
+	}
+	if a.Status != 0 {
+		resp, err := stubError(a.Status)
+		return nil, resp, err
+	}
+	events := feeds[username]
+	perPage, page := 30, 1
+	if opts != nil {
+		perPage, page = cmp.Or(opts.PerPage, perPage), cmp.Or(opts.Page, page)
+	}
+	if etag, _ := ctx.Value(ifNoneMatchKey{}).(string); page == 1 && a.ETag != "" && etag == a.ETag {
+		resp, err := stubError(http.StatusNotModified)
+		return nil, resp, err
+	}
+	lastPage := max((len(events)+perPage-1)/perPage, 1)
+	if page > lastPage {
+		// Like the API past its 300 event window
+		resp, err := stubError(http.StatusUnprocessableEntity)
+		return nil, resp, err
+	}
+	start := (page - 1) * perPage
+	resp := stubResponse(http.StatusOK, page, lastPage)
+	if page == 1 && a.ETag != "" {
+		resp.Header.Set("ETag", a.ETag)
+	}
+	return events[start:min(start+perPage, len(events))], resp, nil
+}
+
+func (a stubAPI) GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	return nil, nil, errNotStubbed
+}
+
+func (a stubAPI) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	return nil, nil, errNotStubbed
+}
+
+func (a stubAPI) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return nil, nil, errNotStubbed
+}
+
+func (a stubAPI) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	return nil, nil, errNotStubbed
+}
+
+func (a stubAPI) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	return nil, nil, errNotStubbed
+}
+
+func (a stubAPI) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	r, ok := a.Repos[owner+"/"+repo]
+	if !ok {
+		resp, err := stubError(http.StatusNotFound)
+		return nil, resp, err
+	}
+	return r, stubResponse(http.StatusOK, 0, 0), nil
+}
+
+func (a stubAPI) SearchIssues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error) {
+	return &github.IssuesSearchResult{Total: github.Int(0)}, stubResponse(http.StatusOK, 0, 0), nil
+}
+
+func (a stubAPI) ListRepositoryEvents(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	var events []*github.Event
+	for _, userEvents := range a.Events {
+		for _, event := range userEvents {
+			if event.GetRepo().GetName() == owner+"/"+repo {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, stubResponse(http.StatusOK, 0, 0), nil
+}
+
+func (a stubAPI) GetLatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error) {
+	resp, err := stubError(http.StatusNotFound)
+	return nil, resp, err
+}
+
+func (a stubAPI) GetRelease(ctx context.Context, owner, repo string, id int64) (*github.RepositoryRelease, *github.Response, error) {
+	return nil, nil, errNotStubbed
+}
+
+func (a stubAPI) IsStarred(ctx context.Context, owner, repo string) (bool, *github.Response, error) {
+	return a.Starred[owner+"/"+repo], stubResponse(http.StatusOK, 0, 0), nil
+}
+
+func (a stubAPI) Star(ctx context.Context, owner, repo string) (*github.Response, error) {
+	return stubResponse(http.StatusNoContent, 0, 0), nil
+}
+
+func (a stubAPI) Unstar(ctx context.Context, owner, repo string) (*github.Response, error) {
+	return stubResponse(http.StatusNoContent, 0, 0), nil
+}
+
+func (a stubAPI) IsFollowing(ctx context.Context, user, target string) (bool, *github.Response, error) {
+	return false, nil, errNotStubbed
+}
+
+func (a stubAPI) Follow(ctx context.Context, user string) (*github.Response, error) {
+	return nil, errNotStubbed
+}
+
+func (a stubAPI) Unfollow(ctx context.Context, user string) (*github.Response, error) {
+	return nil, errNotStubbed
+}
+
+// newStubAPI returns a stubAPI serving n events of octocat, a minute apart
+// (newest first)
+func newStubAPI(n int) stubAPI {
+	events := make([]*github.Event, n)
+	for i := range events {
+		events[i] = &github.Event{
+			ID:        github.String(fmt.Sprint(n - i)),
+			Type:      github.String("WatchEvent"),
+			Actor:     &github.User{Login: github.String("octocat")},
+			Repo:      &github.Repository{Name: github.String(fmt.Sprintf("octocat/repo-%d", n-i))},
+			CreatedAt: &github.Timestamp{Time: testTime.Add(-time.Duration(i) * time.Minute)},
+		}
+	}
+	return stubAPI{
+		Viewer: "octocat",
+		Users:  map[string]*github.User{"octocat": {Login: github.String("octocat")}},
+		Events: map[string][]*github.Event{"octocat": events},
+	}
+}
+
+// itemIDs are the IDs of the events of items
+func itemIDs(items []eventItem) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.Event.GetID()
+	}
+	return ids
+}
+
+func TestFetchEventsPaging(t *testing.T) {
+	setupTestHome(t)
+	api := newStubAPI(75)
+	feed, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{API: api, PerPage: 30})
+	if err != nil {
+		t.Fatalf("fetchEvents() error = %v", err)
+	}
+	if got := len(feed.Items); got != 75 {
+		t.Fatalf("fetched %d events over every page, want 75", got)
+	}
+	if first, last := feed.Items[0].Event.GetID(), feed.Items[74].Event.GetID(); first != "75" || last != "1" {
+		t.Errorf("events run from %s to %s, want newest (75) to oldest (1)", first, last)
+	}
+	if feed.Next != (pageCursor{}) {
+		t.Errorf("Next = %+v after the last page, want none", feed.Next)
+	}
+}
+
+func TestFetchEventsLoadMore(t *testing.T) {
+	setupTestHome(t)
+	api := newStubAPI(75)
+	opts := fetchOptions{API: api, PerPage: 30, Count: 40}
+	feed, err := fetchEvents(context.Background(), api, "octocat", opts)
+	if err != nil {
+		t.Fatalf("fetchEvents() error = %v", err)
+	}
+	if got := len(feed.Items); got != 40 {
+		t.Fatalf("fetched %d events, want 40", got)
+	}
+	if want := (pageCursor{Page: 2, Offset: 10, PerPage: 30}); feed.Next != want {
+		t.Fatalf("Next = %+v, want %+v", feed.Next, want)
+	}
+
+	opts.From = feed.Next
+	more, err := fetchEvents(context.Background(), api, "octocat", opts)
+	if err != nil {
+		t.Fatalf("fetchEvents() from %+v error = %v", opts.From, err)
+	}
+	if got := itemIDs(more.Items); len(got) != 35 || got[0] != "35" || got[34] != "1" {
+		t.Errorf("loaded %v, want events 35 to 1", got)
+	}
+}
+
+func TestListEventsPastWindow(t *testing.T) {
+	api := newStubAPI(10)
+	opts := fetchOptions{From: pageCursor{Page: 5, PerPage: 30}}
+	raw, _, next, _, _, err := listEvents(context.Background(), api, "octocat", true, opts)
+	if err != nil {
+		t.Fatalf("listEvents() past the last page error = %v, want the end of the events", err)
+	}
+	if len(raw) != 0 || next != (pageCursor{}) {
+		t.Errorf("listEvents() past the last page = %d events, next %+v, want none", len(raw), next)
+	}
+}
+
+func TestFetchEventsNotModified(t *testing.T) {
+	setupTestHome(t)
+	api := newStubAPI(5)
+	api.ETag = `"v1"`
+	feed, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{API: api})
+	if err != nil {
+		t.Fatalf("fetchEvents() error = %v", err)
+	}
+	if feed.ETag != api.ETag || feed.NotModified {
+		t.Fatalf("ETag = %q, NotModified = %v, want %q and false", feed.ETag, feed.NotModified, api.ETag)
+	}
+
+	refresh, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{API: api, ETag: feed.ETag})
+	if err != nil {
+		t.Fatalf("fetchEvents() with the ETag error = %v", err)
+	}
+	if !refresh.NotModified || len(refresh.Items) != 0 || refresh.ETag != feed.ETag {
+		t.Errorf("refresh = %d events, NotModified %v, ETag %q, want none, true and %q",
+			len(refresh.Items), refresh.NotModified, refresh.ETag, feed.ETag)
+	}
+
+	api.ETag = `"v2"`
+	changed, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{API: api, ETag: feed.ETag})
+	if err != nil {
+		t.Fatalf("fetchEvents() with a stale ETag error = %v", err)
+	}
+	if changed.NotModified || len(changed.Items) != 5 || changed.ETag != api.ETag {
+		t.Errorf("changed = %d events, NotModified %v, ETag %q, want 5, false and %q",
+			len(changed.Items), changed.NotModified, changed.ETag, api.ETag)
+	}
+}
+
+func TestFetchEventsErrors(t *testing.T) {
+	setupTestHome(t)
+	t.Run("unknown user", func(t *testing.T) {
+		api := newStubAPI(5)
+		_, err := fetchEvents(context.Background(), api, "octocta", fetchOptions{API: api})
+		var unknownErr *unknownUserError
+		if !errors.As(err, &unknownErr) {
+			t.Errorf("fetchEvents() error = %v, want an unknownUserError", err)
+		}
+	})
+	t.Run("server error", func(t *testing.T) {
+		api := newStubAPI(5)
+		api.Status = http.StatusInternalServerError
+		_, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{API: api})
+		var errResp *github.ErrorResponse
+		if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusInternalServerError {
+			t.Errorf("fetchEvents() error = %v, want a 500 error response", err)
+		}
+	})
+	t.Run("past the window", func(t *testing.T) {
+		api := newStubAPI(5)
+		api.Status = http.StatusUnprocessableEntity
+		_, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{API: api})
+		if err == nil {
+			t.Error("fetchEvents() of a first page past the window succeeded, want an error")
+		}
+	})
+}
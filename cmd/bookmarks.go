@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v66/github"
+)
+
+// bookmarkMarker is shown in front of bookmarked events
+var bookmarkMarker = "🔖 "
+
+// bookmark is a saved event
+type bookmark struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	Type         string    `json:"type"`
+	Repo         string    `json:"repo"`
+	Description  string    `json:"description"`
+	URL          string    `json:"url"`
+	CreatedAt    time.Time `json:"created_at"`
+	BookmarkedAt time.Time `json:"bookmarked_at"`
+}
+
+// bookmarksPath is where bookmarks are stored (next to the config file)
+func bookmarksPath() (string, error) {
+	dir := filepath.Dir(configPath)
+	if configPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config", "gitfamous")
+	}
+	return filepath.Join(dir, "bookmarks.json"), nil
+}
+
+func loadBookmarks() ([]bookmark, error) {
+	fname, err := bookmarksPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var bookmarks []bookmark
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return nil, fmt.Errorf("failed to parse bookmarks %s: %v", fname, err)
+	}
+	return bookmarks, nil
+}
+
+func saveBookmarks(bookmarks []bookmark) error {
+	fname, err := bookmarksPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fname, data, 0o600)
+}
+
+// eventURL returns the web URL of the issue, PR, comment or release an event is
+// about, falling back to the repository
+func eventURL(item eventItem, webURL string) string {
+	if item.Event != nil {
+		if payload, err := item.Event.ParsePayload(); err == nil {
+			var u string
+			switch p := payload.(type) {
+			case *github.IssuesEvent:
+				u = p.GetIssue().GetHTMLURL()
+			case *github.IssueCommentEvent:
+				u = p.GetComment().GetHTMLURL()
+			case *github.PullRequestEvent:
+				u = p.GetPullRequest().GetHTMLURL()
+			case *github.PullRequestReviewEvent:
+				u = p.GetReview().GetHTMLURL()
+			case *github.PullRequestReviewCommentEvent:
+				u = p.GetComment().GetHTMLURL()
+			case *github.ReleaseEvent:
+				u = p.GetRelease().GetHTMLURL()
+			case *github.CommitCommentEvent:
+				u = p.GetComment().GetHTMLURL()
+			}
+			if u != "" {
+				return u
+			}
+		}
+	}
+	return webURL + "/" + item.Repository.Name
+}
+
+// loadBookmarkIDs refreshes the set of bookmarked event IDs used to mark rows
+func (m *model) loadBookmarkIDs() {
+	bookmarks, err := loadBookmarks()
+	if err != nil {
+		logger.Debug("loading bookmarks", "error", err)
+		return
+	}
+	m.bookmarkIDs = make(map[string]bool, len(bookmarks))
+	for _, b := range bookmarks {
+		m.bookmarkIDs[b.ID] = true
+	}
+}
+
+// toggleBookmark bookmarks the selected event, or removes its bookmark
+func (m *model) toggleBookmark() {
+	item, ok := m.selectedEvent()
+	if !ok || item.Event == nil {
+		return
+	}
+	bookmarks, err := loadBookmarks()
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	id := item.Event.GetID()
+	if i := slices.IndexFunc(bookmarks, func(b bookmark) bool { return b.ID == id }); i >= 0 {
+		bookmarks = slices.Delete(bookmarks, i, i+1)
+		m.status = "removed bookmark"
+	} else {
+		bookmarks = append(bookmarks, bookmark{
+			ID:           id,
+			Username:     m.username,
+			Type:         item.Type,
+			Repo:         item.Repository.Name,
+			Description:  item.Description,
+			URL:          eventURL(item, m.webURL()),
+			CreatedAt:    item.CreatedAt,
+			BookmarkedAt: time.Now(),
+		})
+		m.status = "bookmarked"
+	}
+	if err := saveBookmarks(bookmarks); err != nil {
+		m.status = fmt.Sprintf("failed to save bookmarks: %v", err)
+		return
+	}
+	m.loadBookmarkIDs()
+	m.setupTable()
+}
+
+// openBookmarks shows the bookmarks view
+func (m *model) openBookmarks() {
+	bookmarks, err := loadBookmarks()
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	// Most recently bookmarked first
+	slices.Reverse(bookmarks)
+	m.bookmarks = bookmarks
+	m.showBookmarks = true
+	m.setupBookmarksTable()
+}
+
+func (m *model) setupBookmarksTable() {
+	userWidth, repoWidth := len("User"), len("Repository")
+	for _, b := range m.bookmarks {
+		userWidth = max(userWidth, len(b.Username))
+		repoWidth = max(repoWidth, len(b.Repo))
+	}
+	// 4 columns worth of cell padding plus the table border and some right padding
+	descWidth := m.termWidth() - 14 - userWidth - repoWidth - 9 - 16
+	var rows []table.Row
+	for _, b := range m.bookmarks {
+		rows = append(rows, table.Row{humanTime(b.CreatedAt), b.Username, b.Repo, b.Description})
+	}
+	cursor := m.bookmarksTable.Cursor()
+	m.bookmarksTable = table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Date", Width: 14},
+			{Title: "User", Width: userWidth},
+			{Title: "Repository", Width: repoWidth},
+			{Title: "Description", Width: max(descWidth, 20)},
+		}),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(min(len(rows)+1, 30)),
+		table.WithKeyMap(m.keys.KeyMap),
+	)
+	m.bookmarksTable.SetStyles(m.tableStyles)
+	m.bookmarksTable.MoveDown(min(cursor, len(rows)-1))
+}
+
+func (m model) updateBookmarks(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		cursor := m.bookmarksTable.Cursor()
+		switch {
+		case msg.String() == "ctrl+c":
+			return m, tea.Quit
+		case msg.String() == "esc", key.Matches(msg, m.keys.Bookmarks), key.Matches(msg, m.keys.Quit):
+			m.showBookmarks = false
+			return m, nil
+		case key.Matches(msg, m.keys.Open):
+			if cursor >= 0 && cursor < len(m.bookmarks) {
+				if _, err := url.ParseRequestURI(m.bookmarks[cursor].URL); err != nil {
+					m.status = fmt.Sprintf("invalid URL: %v", err)
+				} else if err := openURL(m.bookmarks[cursor].URL); err != nil {
+					m.status = fmt.Sprintf("failed to open URL: %v", err)
+				}
+			}
+			return m, nil
+		case msg.String() == "x", msg.String() == "delete":
+			if cursor >= 0 && cursor < len(m.bookmarks) {
+				m.bookmarks = slices.Delete(m.bookmarks, cursor, cursor+1)
+				// Stored oldest first
+				stored := slices.Clone(m.bookmarks)
+				slices.Reverse(stored)
+				if err := saveBookmarks(stored); err != nil {
+					m.status = fmt.Sprintf("failed to save bookmarks: %v", err)
+				}
+				m.setupBookmarksTable()
+				m.loadBookmarkIDs()
+				m.setupTable()
+			}
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.bookmarksTable, cmd = m.bookmarksTable.Update(msg)
+	return m, cmd
+}
+
+func (m model) bookmarksView() string {
+	if len(m.bookmarks) == 0 {
+		return "No bookmarks yet, press b on an event to bookmark it\n\n  esc back\n"
+	}
+	title := fmt.Sprintf("  %d bookmark(s)", len(m.bookmarks))
+	footer := "  enter open • x remove • esc back"
+	if m.status != "" {
+		footer = "  " + m.status
+	}
+	return title + "\n" + baseTableStyle.Render(m.bookmarksTable.View()) + "\n" + footer + "\n"
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// cachedEvents is the on-disk cache of the last events fetched for a user
+type cachedEvents struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Events    []*github.Event `json:"events"`
+}
+
+func cacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitfamous"), nil
+}
+
+// cachePath returns where the user's events are cached; the users of other hosts than
+// github.com get a directory per host so the same login on two hosts doesn't share a cache
+func cachePath(username, host string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	if host != "" && host != defaultHost {
+		dir = filepath.Join(dir, host)
+	}
+	return filepath.Join(dir, username+".json"), nil
+}
+
+func saveCache(username, host string, events []*github.Event) error {
+	fname, err := cachePath(username, host)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cachedEvents{FetchedAt: time.Now(), Events: events})
+	if err != nil {
+		return err
+	}
+	logger.Debug("caching events", "user", username, "events", len(events), "path", fname)
+	return os.WriteFile(fname, data, 0o600)
+}
+
+// mergeCache adds the newer events of an incremental refresh to the top of the
+// user's cached events, keeping as many as the events API serves
+func mergeCache(username, host string, newer []*github.Event) error {
+	cache, err := loadCache(username, host)
+	if err != nil {
+		return saveCache(username, host, newer)
+	}
+	seen := make(map[string]bool, len(newer))
+	for _, event := range newer {
+		seen[event.GetID()] = true
+	}
+	events := newer
+	for _, event := range cache.Events {
+		if !seen[event.GetID()] {
+			events = append(events, event)
+		}
+	}
+	return saveCache(username, host, events[:min(len(events), eventsAPILimit)])
+}
+
+func loadCache(username, host string) (*cachedEvents, error) {
+	fname, err := cachePath(username, host)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no cached events for user %s", username)
+		}
+		return nil, err
+	}
+	var cache cachedEvents
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse cached events for user %s: %v", username, err)
+	}
+	return &cache, nil
+}
+
+// recentUsers returns the users with cached events, most recently viewed first
+func recentUsers() []string {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	type recent struct {
+		name    string
+		modTime time.Time
+	}
+	var users []recent
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		users = append(users, recent{name: name, modTime: info.ModTime()})
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].modTime.After(users[j].modTime)
+	})
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.name
+	}
+	return names
+}
+
+// fetchCachedEvents returns the user's events from the cache
+func fetchCachedEvents(username string, opts fetchOptions) (*eventFeed, error) {
+	cache, err := loadCache(username, opts.Host)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("using cached events", "user", username, "events", len(cache.Events), "fetched_at", cache.FetchedAt)
+	selected, _ := selectEvents(cache.Events, opts, nil)
+	items, err := toEventItems(username, selected, opts)
+	if err != nil {
+		return nil, err
+	}
+	feed := &eventFeed{Items: items, CachedAt: cache.FetchedAt}
+	if feed.Streak, err = loadStreak(username); err != nil {
+		logger.Debug("failed to load streak", "user", username, "error", err)
+	}
+	return feed, nil
+}
+
+// isNetworkError reports whether err was caused by the network being unreachable
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &netErr) || errors.As(err, &urlErr)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCacheKeyedByHost(t *testing.T) {
+	setupTestHome(t)
+	if err := saveCache("octocat", defaultHost, newStubAPI(3).Events["octocat"]); err != nil {
+		t.Fatalf("saveCache() error = %v", err)
+	}
+	if err := saveCache("octocat", "ghe.example.com", newStubAPI(1).Events["octocat"]); err != nil {
+		t.Fatalf("saveCache() error = %v", err)
+	}
+
+	for host, want := range map[string]int{"": 3, defaultHost: 3, "ghe.example.com": 1} {
+		feed, err := fetchCachedEvents("octocat", fetchOptions{Host: host})
+		if err != nil {
+			t.Fatalf("fetchCachedEvents(%q) error = %v", host, err)
+		}
+		if len(feed.Items) != want {
+			t.Errorf("%d cached events of octocat on %q, want %d", len(feed.Items), host, want)
+		}
+	}
+	if _, err := loadCache("octocat", "other.example.com"); err == nil {
+		t.Error("loadCache() found events of octocat on a host they were never fetched from")
+	}
+	if got := recentUsers(); !slices.Equal(got, []string{"octocat"}) {
+		t.Errorf("recentUsers() = %q, want only octocat", got)
+	}
+}
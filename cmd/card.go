@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	cardOutput string
+	cardFormat string
+)
+
+const (
+	cardWidth   = 495
+	cardHeight  = 195
+	cardWeeks   = 13 // the events API only goes back 90 days
+	cardCell    = 11
+	cardGap     = 3
+	cardTopRows = 4
+)
+
+// cardLevels are the heatmap colors from no activity to the busiest days
+var cardLevels = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+// cardCount is a name with its number of events
+type cardCount struct {
+	Name  string
+	Count int
+}
+
+// cardStats is the activity summary drawn on the card
+type cardStats struct {
+	User  string
+	Total int
+	Days  int
+	Types []cardCount
+	Repos []cardCount
+	// Heatmap is the events per day by week (oldest first) and weekday
+	Heatmap [cardWeeks][7]int
+	Max     int
+}
+
+// topCounts returns the n largest counts, breaking ties by name
+func topCounts(counts map[string]int, n int) []cardCount {
+	var top []cardCount
+	for name, count := range counts {
+		top = append(top, cardCount{Name: name, Count: count})
+	}
+	slices.SortFunc(top, func(a, b cardCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return top[:min(len(top), n)]
+}
+
+// buildCardStats summarizes the events for the card as of now
+func buildCardStats(user string, items []eventItem, now time.Time) cardStats {
+	stats := cardStats{User: user, Total: len(items)}
+	// The heatmap ends with the current week
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := today.AddDate(0, 0, -int(today.Weekday())-7*(cardWeeks-1))
+	types := make(map[string]int)
+	repos := make(map[string]int)
+	var oldest time.Time
+	for _, item := range items {
+		types[strings.TrimSuffix(item.Type, "Event")]++
+		repos[item.Repository.Name]++
+		created := item.CreatedAt.In(now.Location())
+		if oldest.IsZero() || created.Before(oldest) {
+			oldest = created
+		}
+		day := int(created.Sub(start).Hours() / 24)
+		if day < 0 || day >= cardWeeks*7 {
+			continue
+		}
+		stats.Heatmap[day/7][day%7]++
+		stats.Max = max(stats.Max, stats.Heatmap[day/7][day%7])
+	}
+	if !oldest.IsZero() {
+		stats.Days = max(int(now.Sub(oldest).Hours()/24), 1)
+	}
+	stats.Types = topCounts(types, 3)
+	stats.Repos = topCounts(repos, cardTopRows)
+	return stats
+}
+
+// level returns the heatmap color index of a day with count events
+func (s cardStats) level(count int) int {
+	if count == 0 || s.Max == 0 {
+		return 0
+	}
+	return 1 + (count-1)*(len(cardLevels)-1)/s.Max
+}
+
+// cardCellRect is a heatmap square
+type cardCellRect struct {
+	X, Y  int
+	Color string
+}
+
+// Cells lays out the heatmap squares
+func (s cardStats) Cells() []cardCellRect {
+	var cells []cardCellRect
+	for week := range cardWeeks {
+		for day := range 7 {
+			cells = append(cells, cardCellRect{
+				X:     25 + week*(cardCell+cardGap),
+				Y:     70 + day*(cardCell+cardGap),
+				Color: cardLevels[s.level(s.Heatmap[week][day])],
+			})
+		}
+	}
+	return cells
+}
+
+// Subtitle describes the time window of the card
+func (s cardStats) Subtitle() string {
+	return fmt.Sprintf("%d events in the last %d days", s.Total, s.Days)
+}
+
+// TypeSummary lists the most common event types
+func (s cardStats) TypeSummary() string {
+	var parts []string
+	for _, t := range s.Types {
+		parts = append(parts, fmt.Sprintf("%s %d", t.Name, t.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+var cardSVGTemplate = template.Must(template.New("card").Funcs(template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+	"mul": func(a, b int) int { return a * b },
+}).Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+  <style>
+    text { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; fill: #24292f; }
+    .title { font-size: 18px; font-weight: 600; fill: #0969da; }
+    .dim { font-size: 12px; fill: #57606a; }
+    .repo { font-size: 13px; }
+  </style>
+  <rect x="0.5" y="0.5" width="{{add .Width -1}}" height="{{add .Height -1}}" rx="4.5" fill="#ffffff" stroke="#d0d7de"/>
+  <text x="25" y="35" class="title">{{.Stats.User}}'s GitHub activity</text>
+  <text x="25" y="55" class="dim">{{.Stats.Subtitle}}</text>
+  {{- range .Stats.Cells}}
+  <rect x="{{.X}}" y="{{.Y}}" width="{{$.Cell}}" height="{{$.Cell}}" rx="2" fill="{{.Color}}"/>
+  {{- end}}
+  <text x="{{.Column}}" y="80" class="dim">Top repositories</text>
+  {{- range $i, $r := .Stats.Repos}}
+  <text x="{{$.Column}}" y="{{add 100 (mul $i 18)}}" class="repo">{{$r.Name}} <tspan class="dim">{{$r.Count}}</tspan></text>
+  {{- end}}
+  <text x="{{.Column}}" y="175" class="dim">{{.Stats.TypeSummary}}</text>
+</svg>
+`))
+
+// renderCardSVG draws the card as an SVG document
+func renderCardSVG(stats cardStats) ([]byte, error) {
+	var out bytes.Buffer
+	err := cardSVGTemplate.Execute(&out, map[string]any{
+		"Width":  cardWidth,
+		"Height": cardHeight,
+		"Cell":   cardCell,
+		"Column": 25 + cardWeeks*(cardCell+cardGap) + 20,
+		"Stats":  stats,
+	})
+	return out.Bytes(), err
+}
+
+// hexColor parses a #rrggbb color
+func hexColor(hex string) color.RGBA {
+	var c color.RGBA
+	c.A = 0xff
+	fmt.Sscanf(hex, "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return c
+}
+
+// renderCardPNG draws the card as a PNG image
+func renderCardPNG(stats cardStats) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	fill := func(r image.Rectangle, hex string) {
+		draw.Draw(img, r, &image.Uniform{hexColor(hex)}, image.Point{}, draw.Src)
+	}
+	text := func(x, y int, s, hex string) {
+		d := &font.Drawer{Dst: img, Src: &image.Uniform{hexColor(hex)}, Face: basicfont.Face7x13, Dot: fixed.P(x, y)}
+		d.DrawString(s)
+	}
+
+	fill(img.Bounds(), "#d0d7de")
+	fill(image.Rect(1, 1, cardWidth-1, cardHeight-1), "#ffffff")
+	text(25, 35, stats.User+"'s GitHub activity", "#0969da")
+	text(25, 55, stats.Subtitle(), "#57606a")
+	for _, cell := range stats.Cells() {
+		fill(image.Rect(cell.X, cell.Y, cell.X+cardCell, cell.Y+cardCell), cell.Color)
+	}
+	column := 25 + cardWeeks*(cardCell+cardGap) + 20
+	text(column, 80, "Top repositories", "#57606a")
+	for i, repo := range stats.Repos {
+		text(column, 100+i*18, fmt.Sprintf("%s %d", repo.Name, repo.Count), "#24292f")
+	}
+	text(column, 175, stats.TypeSummary(), "#57606a")
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+var cardCmd = &cobra.Command{
+	Use:   "card <username>",
+	Short: "Render an activity summary card as an SVG or PNG image",
+	Long: `Render a user's recent activity (event counts, a daily heatmap and their top
+repositories) as an SVG or PNG image for embedding in a README or sharing.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, users, err := setupSubcommand(args)
+		if err != nil {
+			return err
+		}
+		user := users[0]
+		if cardOutput == "" {
+			cardOutput = user.Name + "." + cmp.Or(cardFormat, "svg")
+		}
+		format := cardFormat
+		if format == "" {
+			format = strings.TrimPrefix(filepath.Ext(cardOutput), ".")
+		}
+
+		client, err := newClient(user.Token, user.Host)
+		if err != nil {
+			return err
+		}
+		feed, err := fetchEvents(context.Background(), githubAPI{client}, user.Name, fetchOptions{PerPage: 100, Host: user.Host})
+		if err != nil {
+			return err
+		}
+		stats := buildCardStats(user.Name, feed.Items, time.Now())
+
+		var data []byte
+		switch strings.ToLower(format) {
+		case "svg":
+			data, err = renderCardSVG(stats)
+		case "png":
+			data, err = renderCardPNG(stats)
+		default:
+			return fmt.Errorf("unsupported card format %s (must be svg or png)", format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to render card: %v", err)
+		}
+		if cardOutput == "-" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(cardOutput, data, 0o644); err != nil {
+			return err
+		}
+		logger.Info("saved card", "path", cardOutput)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cardCmd)
+	cardCmd.Flags().StringVarP(&cardOutput, "output", "o", "", "Output file ('-' for stdout, default <username>.svg)")
+	cardCmd.Flags().StringVar(&cardFormat, "format", "", "Image format: svg or png (default from the output file extension)")
+	cardCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	cardCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	cardCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	cardCmd.RegisterFlagCompletionFunc("account", completeAccount)
+	cardCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"svg", "png"}, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// coalesceKey groups consecutive events that can be summarized as a single row;
+// an empty key means the event is never coalesced.
+func coalesceKey(item eventItem) string {
+	switch item.Type {
+	case "WatchEvent":
+		// Stars are usually spread across many repositories
+		return item.Type
+	case "PushEvent":
+		if item.Event == nil {
+			return ""
+		}
+		ref := ""
+		if payload, err := item.Event.ParsePayload(); err == nil {
+			if push, ok := payload.(*github.PushEvent); ok {
+				ref = push.GetRef()
+			}
+		}
+		return item.Type + "|" + item.Repository.Name + "|" + ref
+	case "IssueCommentEvent", "PullRequestReviewCommentEvent", "PullRequestReviewEvent", "CreateEvent", "DeleteEvent":
+		return item.Type + "|" + item.Repository.Name
+	}
+	return ""
+}
+
+// coalesceEvents collapses bursts of similar consecutive events into summary rows
+func coalesceEvents(items []eventItem) []eventItem {
+	var out []eventItem
+	for i := 0; i < len(items); {
+		key := coalesceKey(items[i])
+		j := i + 1
+		for key != "" && j < len(items) && coalesceKey(items[j]) == key {
+			j++
+		}
+		if j-i == 1 {
+			out = append(out, items[i])
+		} else {
+			out = append(out, summarizeEvents(items[i:j]))
+		}
+		i = j
+	}
+	return out
+}
+
+// summarizeEvents builds a single row for a burst of events (newest first)
+func summarizeEvents(group []eventItem) eventItem {
+	item := group[0]
+	item.Coalesced = len(group)
+	switch item.Type {
+	case "PushEvent":
+		var commits int
+		var ref string
+		for _, e := range group {
+			if payload, err := e.Event.ParsePayload(); err == nil {
+				if push, ok := payload.(*github.PushEvent); ok {
+					commits += max(push.GetSize(), len(push.Commits))
+					ref = push.GetRef()
+				}
+			}
+		}
+		item.Description = fmt.Sprintf(" Pushed %d commit(s) across %d pushes to %s", commits, len(group), strings.TrimPrefix(ref, "refs/heads/"))
+	case "WatchEvent":
+		var repos []string
+		for _, e := range group {
+			repos = append(repos, e.Repository.Name)
+		}
+		item.Description = fmt.Sprintf("⭐️ Starred %d repositories: %s", len(group), strings.Join(repos, ", "))
+	default:
+		item.Description = fmt.Sprintf("%s (×%d)", item.Description, len(group))
+	}
+	return item
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// How the repository of an event is shown in the events table (--repo-column)
+const (
+	repoColumnFull  = "full"  // owner/repo
+	repoColumnSplit = "split" // separate Owner and Repo columns
+	repoColumnShort = "short" // no owner on the viewed user's own repositories
+)
+
+// repoColumns are the valid --repo-column values
+var repoColumns = []string{repoColumnFull, repoColumnSplit, repoColumnShort}
+
+// ownRepo drops the owner from the viewed user's own repositories
+func (m model) ownRepo(name string) string {
+	if owner, repo, ok := strings.Cut(name, "/"); ok && strings.EqualFold(owner, m.username) {
+		return repo
+	}
+	return name
+}
+
+// splitRepo returns the owner and name of a repository for the Owner and Repo columns
+func splitRepo(name string) (string, string) {
+	if owner, repo, ok := strings.Cut(name, "/"); ok {
+		return owner, repo
+	}
+	return "", name
+}
+
+// splitIcon splits the leading icons of an event description (e.g. " " of a
+// reviewed PR) from its text for the Type column
+func splitIcon(desc string) (icon, text string) {
+	end := strings.IndexFunc(desc, func(r rune) bool { return r != ' ' && !isIcon(r) })
+	if end < 0 {
+		return strings.TrimSpace(desc), ""
+	}
+	return strings.TrimSpace(desc[:end]), desc[end:]
+}
+
+// isIcon reports whether r is part of a Nerd Font icon or emoji
+func isIcon(r rune) bool {
+	return unicode.Is(unicode.Co, r) || unicode.Is(unicode.So, r) || unicode.Is(unicode.Variation_Selector, r)
+}
+
+// typeTooltip explains the Type icon of an event from the legend
+func typeTooltip(item eventItem) string {
+	icon, _ := splitIcon(item.Description)
+	meaning := strings.TrimSuffix(item.Type, "Event")
+	for _, e := range eventLegend {
+		if e.eventType != item.Type {
+			continue
+		}
+		if e.icon == icon {
+			return e.meaning
+		}
+		meaning = e.meaning
+	}
+	return meaning
+}
+
+// toggleTypeFilter shows only the events of the selected row's type, or all of them again
+func (m *model) toggleTypeFilter() {
+	s := m.viewState()
+	if s.typeFilter != "" {
+		s.typeFilter = ""
+	} else if item, ok := m.selectedEvent(); ok {
+		s.typeFilter = item.Type
+	} else {
+		return
+	}
+	m.pushView()
+	m.restoreView(s)
+	if m.typeFilter == "" {
+		m.status = "showing all event types"
+	} else {
+		m.status = fmt.Sprintf("showing only %s events (e to show all)", strings.TrimSuffix(m.typeFilter, "Event"))
+	}
+}
+
+// columnSorts are the --sort orders of the columns whose header sorts the events when clicked
+var columnSorts = map[string]string{"Date": "date", "Owner": "repo", "Repo": "repo", "Repository": "repo", "Type": "type"}
+
+// sortByColumn sorts the events by the column whose header is at x, or by date
+// again when they already are
+func (m *model) sortByColumn(x int) {
+	right := 1 // the table border
+	for _, col := range m.table.Columns() {
+		if right += col.Width + 2; x >= right { // and the cell padding
+			continue
+		}
+		by, ok := columnSorts[col.Title]
+		current := cmp.Or(m.fetch.Sort, "date")
+		if !ok || (by == current && by == "date") {
+			return
+		}
+		if by == current {
+			by = "date"
+		}
+		s := m.viewState()
+		s.sort = by
+		m.pushView()
+		m.restoreView(s)
+		m.status = "sorted by " + by
+		return
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v66/github"
+)
+
+type commitItem struct {
+	SHA     string
+	Author  string
+	Message string
+}
+
+// Message type for fetched push commits
+type fetchCommitsMsg struct {
+	username string
+	received bool
+	commits  []commitItem
+	err      error
+}
+
+// pushCommits returns the commits of a push, falling back to the compare API
+// when the event payload doesn't include (all of) them
+func pushCommits(api eventsAPI, repo string, push *github.PushEvent) ([]commitItem, error) {
+	var commits []commitItem
+	for _, c := range push.Commits {
+		commits = append(commits, commitItem{
+			SHA:     c.GetSHA(),
+			Author:  c.GetAuthor().GetName(),
+			Message: c.GetMessage(),
+		})
+	}
+	if len(commits) >= push.GetSize() && len(commits) > 0 {
+		return commits, nil
+	}
+
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name: %s", repo)
+	}
+	comparison, _, err := api.CompareCommits(context.Background(), owner, name, push.GetBefore(), push.GetHead(), &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s...%s: %v", push.GetBefore(), push.GetHead(), err)
+	}
+	commits = nil
+	for _, c := range comparison.Commits {
+		author := c.GetCommit().GetAuthor().GetName()
+		if c.GetAuthor().GetLogin() != "" {
+			author = c.GetAuthor().GetLogin()
+		}
+		commits = append(commits, commitItem{
+			SHA:     c.GetSHA(),
+			Author:  author,
+			Message: c.GetCommit().GetMessage(),
+		})
+	}
+	return commits, nil
+}
+
+func (m *model) openCommits() tea.Cmd {
+	item, ok := m.selectedEvent()
+	if !ok || item.Event == nil || item.Type != "PushEvent" {
+		return nil
+	}
+	payload, err := item.Event.ParsePayload()
+	if err != nil {
+		m.status = fmt.Sprintf("failed to parse push: %v", err)
+		return nil
+	}
+	push, ok := payload.(*github.PushEvent)
+	if !ok {
+		return nil
+	}
+	m.showCommits = true
+	m.commitsLoading = true
+	m.commitsRepo = item.Repository.Name
+	m.commitItems = nil
+	api, username, received := m.api(), m.username, m.fetch.Received
+	return func() tea.Msg {
+		commits, err := pushCommits(api, item.Repository.Name, push)
+		return fetchCommitsMsg{username: username, received: received, commits: commits, err: err}
+	}
+}
+
+func (m *model) setupCommitsTable() {
+	authorWidth := len("Author")
+	for _, c := range m.commitItems {
+		authorWidth = max(authorWidth, len(c.Author))
+	}
+	// 3 columns worth of cell padding plus the table border and some right padding
+	msgWidth := m.termWidth() - authorWidth - 7 - 16
+	var rows []table.Row
+	for _, c := range m.commitItems {
+		sha := c.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		// Only the subject line fits in the table
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		rows = append(rows, table.Row{sha, c.Author, subject})
+	}
+	m.commits = table.New(
+		table.WithColumns([]table.Column{
+			{Title: "SHA", Width: 7},
+			{Title: "Author", Width: authorWidth},
+			{Title: "Message", Width: max(msgWidth, 20)},
+		}),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(min(len(rows)+1, 30)),
+		table.WithKeyMap(m.keys.KeyMap),
+	)
+	m.commits.SetStyles(m.tableStyles)
+}
+
+func (m model) updateCommits(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case fetchCommitsMsg:
+		m.commitsLoading = false
+		if msg.err != nil {
+			m.showCommits = false
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		m.commitItems = msg.commits
+		m.setupCommitsTable()
+		return m, nil
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "ctrl+c":
+			return m, tea.Quit
+		case msg.String() == "esc", msg.String() == "left", key.Matches(msg, m.keys.Quit):
+			m.showCommits = false
+			return m, nil
+		case key.Matches(msg, m.keys.Open):
+			m.openSelectedCommit()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.commits, cmd = m.commits.Update(msg)
+	return m, cmd
+}
+
+func (m *model) openSelectedCommit() {
+	cursor := m.commits.Cursor()
+	if cursor < 0 || cursor >= len(m.commitItems) {
+		return
+	}
+	if m.readOnly {
+		m.status = sshBrowserStatus
+		return
+	}
+	commitURL := fmt.Sprintf("%s/%s/commit/%s", m.webURL(), m.commitsRepo, m.commitItems[cursor].SHA)
+	if _, err := url.ParseRequestURI(commitURL); err != nil {
+		m.status = fmt.Sprintf("invalid URL: %v", err)
+		return
+	}
+	if err := openURL(commitURL); err != nil {
+		m.status = fmt.Sprintf("failed to open URL: %v", err)
+	}
+}
+
+func (m model) commitsView() string {
+	if m.commitsLoading {
+		return fmt.Sprintf("Loading commits for %s...\n", m.commitsRepo)
+	}
+	if len(m.commitItems) == 0 {
+		return fmt.Sprintf("No commits found for this push to %s\n\n  esc back\n", m.commitsRepo)
+	}
+	title := fmt.Sprintf("  %d commit(s) pushed to %s", len(m.commitItems), m.commitsRepo)
+	return title + "\n" + baseTableStyle.Render(m.commits.View()) + "\n  enter open commit • esc/← back\n"
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// compactWidth is the terminal width below which the events table switches to a
+// compact layout (short dates and repos, stacked help) rather than breaking
+const compactWidth = 60
+
+// compact reports whether the terminal is too narrow for the full layout
+func (m model) compact() bool {
+	return m.termWidth() < compactWidth
+}
+
+// shortDate renders an event timestamp in a few characters, e.g. "5m" or "3d"
+// for relative dates and "Jan 2" otherwise
+func (m model) shortDate(t time.Time) string {
+	if m.timeFormat != timeFormatRelative {
+		if m.utc {
+			t = t.UTC()
+		} else {
+			t = t.Local()
+		}
+		if t.Year() != time.Now().Year() {
+			return t.Format("2006-01")
+		}
+		return t.Format("Jan 2")
+	}
+	const day = 24 * time.Hour
+	switch ago := time.Since(t); {
+	case ago < time.Minute:
+		return "now"
+	case ago < time.Hour:
+		return fmt.Sprintf("%dm", ago/time.Minute)
+	case ago < day:
+		return fmt.Sprintf("%dh", ago/time.Hour)
+	case ago < 14*day:
+		return fmt.Sprintf("%dd", ago/day)
+	case ago < 60*day:
+		return fmt.Sprintf("%dw", ago/(7*day))
+	case ago < 365*day:
+		return fmt.Sprintf("%dmo", ago/(30*day))
+	default:
+		return fmt.Sprintf("%dy", ago/(365*day))
+	}
+}
+
+// shortRepo drops the owner of the viewed user's own repositories and shortens
+// the others' to their first letter, e.g. "ipsw" and "g…/go"
+func (m model) shortRepo(name string) string {
+	if own := m.ownRepo(name); own != name {
+		return own
+	}
+	owner, repo, ok := strings.Cut(name, "/")
+	if !ok || owner == "" {
+		return name
+	}
+	ellipsis := "…"
+	if asciiConsole {
+		ellipsis = "~"
+	}
+	return string([]rune(owner)[:1]) + ellipsis + "/" + repo
+}
+
+// compactHelp stacks the short help over as many lines as the width takes
+func (m model) compactHelp() string {
+	styles := m.help.Styles
+	sep := styles.ShortSeparator.Render(m.help.ShortSeparator)
+	width := max(m.termWidth()-2, 1)
+	var lines []string
+	var line string
+	for _, b := range m.keys.ShortHelp() {
+		if !b.Enabled() {
+			continue
+		}
+		item := styles.ShortKey.Render(b.Help().Key) + " " + styles.ShortDesc.Render(b.Help().Desc)
+		switch {
+		case line == "":
+			line = item
+		case lipgloss.Width(line+sep+item) > width:
+			lines = append(lines, line)
+			line = item
+		default:
+			line += sep + item
+		}
+	}
+	return strings.Join(append(lines, line), "\n  ")
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compareA string
+	compareB string
+)
+
+// eventsAPIWindow is how far back the events API goes
+const eventsAPIWindow = 90 * 24 * time.Hour
+
+// compareWindow is a time window of the compare command, e.g. "1w" for the last
+// week or "2w..1w" for the week before that
+type compareWindow struct {
+	spec         string
+	since, until time.Time
+}
+
+// parseCompareWindow parses a window like 1w, 2w..1w or 2024-01-01..2024-02-01
+func parseCompareWindow(spec string) (compareWindow, error) {
+	from, to, _ := strings.Cut(spec, "..")
+	since, err := parseTimeBound(from)
+	if err != nil {
+		return compareWindow{}, err
+	}
+	if since.IsZero() {
+		return compareWindow{}, fmt.Errorf("invalid window %q: expected a start like 1w or 2w..1w", spec)
+	}
+	until, err := parseTimeBound(to)
+	if err != nil {
+		return compareWindow{}, err
+	}
+	w := compareWindow{spec: spec, since: since.Time(), until: time.Now()}
+	if !until.IsZero() {
+		w.until = until.Time()
+	}
+	if !w.since.Before(w.until) {
+		return compareWindow{}, fmt.Errorf("invalid window %q: the start must be before the end", spec)
+	}
+	return w, nil
+}
+
+// contains reports whether t falls in the window
+func (w compareWindow) contains(t time.Time) bool {
+	return !t.Before(w.since) && t.Before(w.until)
+}
+
+// compareRow is the counts of an event type in the two windows
+type compareRow struct {
+	label string
+	a, b  int
+}
+
+// countWindows counts the events of each type (as named in the oneline summary) in windows a and b,
+// busiest first
+func countWindows(items []eventItem, a, b compareWindow) []compareRow {
+	rows := make(map[string]*compareRow)
+	var order []string
+	for _, item := range items {
+		inA, inB := a.contains(item.CreatedAt), b.contains(item.CreatedAt)
+		if !inA && !inB {
+			continue
+		}
+		label := strings.ToLower(strings.TrimSuffix(item.Type, "Event")) + "s"
+		if l, ok := onelineLabels[item.Type]; ok {
+			label = l.plural
+		}
+		row, ok := rows[label]
+		if !ok {
+			row = &compareRow{label: label}
+			rows[label] = row
+			order = append(order, label)
+		}
+		if inA {
+			row.a++
+		}
+		if inB {
+			row.b++
+		}
+	}
+	var out []compareRow
+	for _, label := range order {
+		out = append(out, *rows[label])
+	}
+	slices.SortFunc(out, func(x, y compareRow) int {
+		return cmp.Or(cmp.Compare(y.a+y.b, x.a+x.b), strings.Compare(x.label, y.label))
+	})
+	return out
+}
+
+// compareDelta is the change from b to a, e.g. "+40%", "−10%" or "new"
+func compareDelta(a, b int) string {
+	switch {
+	case a == b:
+		return "±0%"
+	case b == 0:
+		return "new"
+	}
+	return strings.Replace(fmt.Sprintf("%+.0f%%", float64(a-b)/float64(b)*100), "-", "−", 1)
+}
+
+var (
+	compareUpStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("70"))
+	compareDownStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("160"))
+)
+
+// writeComparison prints the counts of both windows side by side with the deltas,
+// followed by a one-line summary like "PRs +40%, pushes −10%"
+func writeComparison(w io.Writer, username string, rows []compareRow, a, b compareWindow) {
+	styled := func(row compareRow) string {
+		delta := compareDelta(row.a, row.b)
+		switch {
+		case row.a > row.b:
+			return compareUpStyle.Render(delta)
+		case row.a < row.b:
+			return compareDownStyle.Render(delta)
+		}
+		return delta
+	}
+	fmt.Fprintf(w, "%s: %s vs %s\n\n", username, a.spec, b.spec)
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "\t%s\t%s\tchange\t\n", a.spec, b.spec)
+	var total compareRow
+	var summary []string
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t\n", row.label, row.a, row.b, styled(row))
+		total.a += row.a
+		total.b += row.b
+		if row.a != row.b {
+			summary = append(summary, row.label+" "+styled(row))
+		}
+	}
+	total.label = "total"
+	fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t\n", total.label, total.a, total.b, styled(total))
+	tw.Flush()
+	if len(summary) > 0 {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(summary, ", "))
+	}
+}
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <username>",
+	Short: "Compare a user's activity between two time windows",
+	Long: `Count a user's events by type in two time windows side by side, with the
+change from the second window to the first, e.g. to track your own momentum
+week over week:
+
+  gitfamous compare blacktop --a 1w --b 2w..1w
+
+Windows are a start (relative like 1w or a date like 2024-01-01) up to now,
+or start..end. The events API only goes back 90 days (and 300 events).`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := parseCompareWindow(compareA)
+		if err != nil {
+			return fmt.Errorf("failed to parse --a: %v", err)
+		}
+		b, err := parseCompareWindow(compareB)
+		if err != nil {
+			return fmt.Errorf("failed to parse --b: %v", err)
+		}
+		_, users, err := setupSubcommand(args)
+		if err != nil {
+			return err
+		}
+		filter, err := parseFilterTypes(filterTypes)
+		if err != nil {
+			return err
+		}
+		earliest := a.since
+		if b.since.Before(earliest) {
+			earliest = b.since
+		}
+		if time.Since(earliest) > eventsAPIWindow {
+			logger.Warn("the events API only goes back 90 days, so older events are missing from the counts")
+		}
+
+		client, err := newClient(users[0].Token, users[0].Host)
+		if err != nil {
+			return err
+		}
+		opts := fetchOptions{Since: timeBound{abs: earliest}, FilterTypes: filter, PerPage: 100, Host: users[0].Host}
+		var items []eventItem
+		feed, err := fetchEvents(context.Background(), githubAPI{client}, users[0].Name, opts)
+		if err != nil && !strings.HasPrefix(err.Error(), "no events found") {
+			return err
+		}
+		if feed != nil {
+			items = feed.Items
+		}
+		writeComparison(os.Stdout, users[0].Name, countWindows(items, a, b), a, b)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+	compareCmd.Flags().StringVar(&compareA, "a", "1w", "Time window to compare (e.g. 1w, 2024-03-01..2024-04-01)")
+	compareCmd.Flags().StringVar(&compareB, "b", "2w..1w", "Time window to compare against")
+	compareCmd.Flags().StringSliceVarP(&filterTypes, "filter", "f", nil, "Comma-separated list of event types or aliases to count")
+	compareCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	compareCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	compareCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	compareCmd.RegisterFlagCompletionFunc("filter", completeFilter)
+	compareCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish]",
+	Short: "Generate the shell completion script",
+	Long: `Generate the shell completion script for gitfamous.
+
+  bash:  source <(gitfamous completion bash)
+  zsh:   gitfamous completion zsh > "${fpath[1]}/_gitfamous"
+  fish:  gitfamous completion fish | source`,
+	Args:                  cobra.ExactArgs(1),
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		}
+		return fmt.Errorf("unsupported shell %s (must be bash, zsh or fish)", args[0])
+	},
+}
+
+// completeUsernames suggests users from the config file and recently viewed users.
+// The config is read with readConfig so completing never runs a token_cmd.
+func completeUsernames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	if conf, err := readConfig(configPath); err == nil {
+		for _, user := range conf.Users {
+			names = append(names, user.Name)
+		}
+		for _, acct := range conf.Accounts {
+			if acct.User != "" {
+				names = append(names, acct.User)
+			}
+		}
+	}
+	names = append(names, recentUsers()...)
+
+	var suggestions []string
+	for _, name := range names {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(toComplete)) &&
+			!slices.Contains(args, name) && !slices.Contains(suggestions, name) {
+			suggestions = append(suggestions, name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFilter suggests aliases and event types for the comma-separated --filter value
+func completeFilter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+	types := slices.Sorted(maps.Keys(filterAliases))
+	types = append(types, validEventTypes...)
+	var suggestions []string
+	for _, t := range types {
+		if strings.HasPrefix(t, toComplete) && !slices.Contains(strings.Split(prefix, ","), t) {
+			suggestions = append(suggestions, prefix+t)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeAccount suggests the account names from the config file
+func completeAccount(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	conf, err := readConfig(configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return slices.Sorted(maps.Keys(conf.Accounts)), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProfile suggests the profile names from the config file
+func completeProfile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	conf, err := readConfig(configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return slices.Sorted(maps.Keys(conf.Profiles)), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.ValidArgsFunction = completeUsernames
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultHost is the host used when an account doesn't set one
+const defaultHost = "github.com"
+
+// User is a GitHub user to track
+type User struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token,omitempty"`
+	// TokenCmd is a shell command that prints the token (e.g. "op read ...")
+	TokenCmd string `yaml:"token_cmd,omitempty"`
+	Account  string `yaml:"account,omitempty"`
+	// Host is filled in from the user's account
+	Host string `yaml:"-"`
+}
+
+// Account is a named set of credentials for a GitHub host
+type Account struct {
+	Host  string `yaml:"host,omitempty"`
+	Type  string `yaml:"type,omitempty"`
+	Token string `yaml:"token,omitempty"`
+	User  string `yaml:"user,omitempty"`
+
+	TokenCmd string `yaml:"token_cmd,omitempty"`
+}
+
+// Profile is a saved view selected with --profile
+type Profile struct {
+	Users   []User   `yaml:"users,omitempty"`
+	Account string   `yaml:"account,omitempty"`
+	Filter  []string `yaml:"filter,omitempty"`
+	Since   string   `yaml:"since,omitempty"`
+	Until   string   `yaml:"until,omitempty"`
+	Count   int      `yaml:"count,omitempty"`
+}
+
+// Notify is a notification sink the watch command sends new events to
+type Notify struct {
+	Type string `yaml:"type"` // slack or discord
+	// URL is the incoming webhook URL (env vars are expanded)
+	URL    string   `yaml:"url"`
+	Filter []string `yaml:"filter,omitempty"` // event types or aliases to notify about (default all)
+	Users  []string `yaml:"users,omitempty"`  // users to notify about (default all)
+}
+
+// SMTP is the mail server the digest command sends email through
+type SMTP struct {
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port,omitempty"` // default 587
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"` // env vars are expanded
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// SSH configures the ssh command
+type SSH struct {
+	HostKey string   `yaml:"host_key,omitempty"` // default ssh_host_ed25519 next to the config (generated if missing)
+	Keys    []SSHKey `yaml:"keys,omitempty"`     // when set, only these keys can connect
+}
+
+// SSHKey is a public key allowed to connect to the ssh command and the users it sees
+type SSHKey struct {
+	Key   string   `yaml:"key"`             // in authorized_keys format
+	Users []string `yaml:"users,omitempty"` // default the users given to the ssh command
+}
+
+// Config is the gitfamous config file
+type Config struct {
+	Users          []User             `yaml:"users"`
+	Accounts       map[string]Account `yaml:"accounts,omitempty"`
+	DefaultAccount string             `yaml:"default_account,omitempty"`
+	Filter         []string           `yaml:"filter,omitempty"`
+	IgnoreUsers    []string           `yaml:"ignore_users,omitempty"` // actors whose events are hidden (bots, mirrors, ...)
+	PinnedRepos    []string           `yaml:"pinned_repos,omitempty"` // repositories whose events are shown first
+	MutedRepos     []string           `yaml:"muted_repos,omitempty"`  // repositories whose events are hidden
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+	Theme          string             `yaml:"theme,omitempty"`
+	TableStyle     tableStyle         `yaml:"table_style,omitempty"`
+	Layout         tableLayout        `yaml:"layout,omitempty"`    // column widths of the events table
+	Templates      map[string]string  `yaml:"templates,omitempty"` // per event type description templates
+	EnterAction    string             `yaml:"enter_action,omitempty"`
+	RepoColumn     string             `yaml:"repo_column,omitempty"` // default --repo-column
+	TypeColumn     bool               `yaml:"type_column,omitempty"` // default --type-column
+	Browser        string             `yaml:"browser,omitempty"`     // command to open links with (%s is the URL)
+	Proxy          string             `yaml:"proxy,omitempty"`
+	CACert         string             `yaml:"ca_cert,omitempty"`
+	Notify         []Notify           `yaml:"notify,omitempty"`
+	SMTP           *SMTP              `yaml:"smtp,omitempty"`
+	SSH            *SSH               `yaml:"ssh,omitempty"`
+}
+
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gitfamous", "config.yml")
+}
+
+// loadConfig reads the config file at path and resolves the tokens in it
+func loadConfig(path string) (*Config, error) {
+	return loadConfigTokens(path, false)
+}
+
+// reloadConfigFile is loadConfig for a config file that changed, only running the
+// token_cmds of new or changed users and accounts
+func reloadConfigFile(path string) (*Config, error) {
+	return loadConfigTokens(path, true)
+}
+
+// tokenCmdOutputs are the tokens printed by the token_cmds loadConfig ran (by command)
+var (
+	tokenCmdMu      sync.Mutex
+	tokenCmdOutputs = map[string]string{}
+)
+
+// loadConfigTokens reads the config file at path and resolves the tokens in it,
+// reusing the output of the token_cmds already run when reuse is set
+func loadConfigTokens(path string, reuse bool) (*Config, error) {
+	conf, err := readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	tokenCmdMu.Lock()
+	defer tokenCmdMu.Unlock()
+	resolve := func(token, tokenCmd string) (string, error) {
+		if token != "" || tokenCmd == "" {
+			return resolveToken(token, tokenCmd)
+		}
+		if cached, ok := tokenCmdOutputs[tokenCmd]; ok && reuse {
+			return cached, nil
+		}
+		token, err := resolveToken(token, tokenCmd)
+		if err == nil {
+			tokenCmdOutputs[tokenCmd] = token
+		}
+		return token, err
+	}
+	for i, user := range conf.Users {
+		token, err := resolve(user.Token, user.TokenCmd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token for user %s: %v", user.Name, err)
+		}
+		conf.Users[i].Token = token
+	}
+	for name, prof := range conf.Profiles {
+		for i, user := range prof.Users {
+			token, err := resolve(user.Token, user.TokenCmd)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get token for user %s in profile %s: %v", user.Name, name, err)
+			}
+			prof.Users[i].Token = token
+		}
+	}
+	for name, acct := range conf.Accounts {
+		token, err := resolve(acct.Token, acct.TokenCmd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token for account %s: %v", name, err)
+		}
+		acct.Token = token
+		conf.Accounts[name] = acct
+	}
+	return conf, nil
+}
+
+// readConfig parses the config file at path as is; a missing file yields an empty config
+func readConfig(path string) (*Config, error) {
+	conf := &Config{}
+	if path == "" {
+		return conf, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return conf, nil
+		}
+		return nil, fmt.Errorf("failed to read config %s: %v", path, err)
+	}
+	if err := yaml.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %v", path, err)
+	}
+	return conf, nil
+}
+
+// saveConfig writes conf to the config file at path
+func saveConfig(path string, conf *Config) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(conf); err != nil {
+		return fmt.Errorf("failed to encode config: %v", err)
+	}
+	return writeConfig(path, buf.Bytes())
+}
+
+// setConfigList sets the list under key in the config file at path (removing it
+// when values is empty), leaving the rest of the file and its comments as they are
+func setConfigList(path, key string, values []string) error {
+	if path == "" {
+		return fmt.Errorf("no config file to save %s in", key)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config %s: %v", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config %s: %v", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to update config %s: not a mapping", path)
+	}
+	list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+	for _, v := range values {
+		list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v})
+	}
+	i := 0
+	for i < len(root.Content)-1 && root.Content[i].Value != key {
+		i += 2
+	}
+	switch {
+	case i < len(root.Content)-1 && len(values) == 0:
+		root.Content = slices.Delete(root.Content, i, i+2)
+	case i < len(root.Content)-1:
+		root.Content[i+1] = list
+	case len(values) > 0:
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, list)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config: %v", err)
+	}
+	return writeConfig(path, buf.Bytes())
+}
+
+// savedConfig is what gitfamous last wrote to the config file, so the config
+// watcher can tell its own saves (e.g. of pins and mutes) from the user's edits
+var (
+	savedConfigMu sync.Mutex
+	savedConfig   []byte
+)
+
+// writeConfig writes data to the config file at path
+func writeConfig(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	savedConfigMu.Lock()
+	defer savedConfigMu.Unlock()
+	// The config may contain tokens
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config %s: %v", path, err)
+	}
+	savedConfig = data
+	return nil
+}
+
+// savedByUs reports whether the config file at path is as gitfamous last wrote it,
+// which only holds once per save
+func savedByUs(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	savedConfigMu.Lock()
+	defer savedConfigMu.Unlock()
+	if savedConfig == nil || !bytes.Equal(data, savedConfig) {
+		return false
+	}
+	savedConfig = nil
+	return true
+}
+
+// resolveToken expands ${VAR} references in token, or runs tokenCmd and uses its output
+func resolveToken(token, tokenCmd string) (string, error) {
+	if token != "" {
+		return os.ExpandEnv(token), nil
+	}
+	if tokenCmd == "" {
+		return "", nil
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", tokenCmd)
+	} else {
+		cmd = exec.Command("sh", "-c", tokenCmd)
+	}
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("token_cmd %q failed: %v", tokenCmd, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// account looks up the named account; an empty name selects the default account (if any)
+func (c *Config) account(name string) (Account, error) {
+	if name == "" {
+		name = c.DefaultAccount
+	}
+	if name == "" {
+		return Account{Host: defaultHost}, nil
+	}
+	acct, ok := c.Accounts[name]
+	if !ok {
+		return Account{}, fmt.Errorf("unknown account %s", name)
+	}
+	switch acct.Type {
+	case "", "github":
+	case "gitlab":
+		return Account{}, fmt.Errorf("account %s: GitLab accounts are not supported yet", name)
+	default:
+		return Account{}, fmt.Errorf("account %s: unknown account type %s", name, acct.Type)
+	}
+	if acct.Host == "" {
+		acct.Host = defaultHost
+	}
+	return acct, nil
+}
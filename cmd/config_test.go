@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestReloadConfigRunsChangedTokenCmds(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token_cmd test uses sh")
+	}
+	dir := t.TempDir()
+	runs := filepath.Join(dir, "runs")
+	// tokenCmd prints the token and logs the run
+	tokenCmd := func(token string) string {
+		return fmt.Sprintf("echo %s >> %s; echo %s", token, runs, token)
+	}
+	path := filepath.Join(dir, "config.yml")
+	writeUsers := func(tokens ...string) {
+		t.Helper()
+		var sb strings.Builder
+		sb.WriteString("users:\n")
+		for i, token := range tokens {
+			fmt.Fprintf(&sb, "  - name: user%d\n    token_cmd: %q\n", i, tokenCmd(token))
+		}
+		if err := os.WriteFile(path, []byte(sb.String()), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ranCmds := func() string {
+		t.Helper()
+		data, _ := os.ReadFile(runs)
+		os.Remove(runs)
+		return strings.Join(strings.Fields(string(data)), ",")
+	}
+
+	writeUsers("a", "b")
+	if _, err := loadConfig(path); err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if got := ranCmds(); got != "a,b" {
+		t.Fatalf("loadConfig() ran the token_cmds of %q, want a,b", got)
+	}
+
+	writeUsers("a", "c")
+	conf, err := reloadConfigFile(path)
+	if err != nil {
+		t.Fatalf("reloadConfigFile() error = %v", err)
+	}
+	if got := ranCmds(); got != "c" {
+		t.Errorf("reloadConfigFile() ran the token_cmds of %q, want only the changed one (c)", got)
+	}
+	if got := conf.Users[0].Token + "," + conf.Users[1].Token; got != "a,c" {
+		t.Errorf("tokens = %s, want a,c", got)
+	}
+}
+
+func TestSavedByUs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("users:\n  - name: octocat\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if savedByUs(path) {
+		t.Error("savedByUs() = true for the user's own edit")
+	}
+	if err := setConfigList(path, "pinned_repos", []string{"blacktop/ipsw"}); err != nil {
+		t.Fatalf("setConfigList() error = %v", err)
+	}
+	if !savedByUs(path) {
+		t.Error("savedByUs() = false after saving the pins")
+	}
+	if savedByUs(path) {
+		t.Error("savedByUs() = true twice for one save")
+	}
+	if err := setConfigList(path, "muted_repos", []string{"octocat/spam"}); err != nil {
+		t.Fatalf("setConfigList() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte("users:\n  - name: blacktop\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if savedByUs(path) {
+		t.Error("savedByUs() = true for an edit after our save")
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	// forceASCII is --ascii
+	forceASCII bool
+	// asciiConsole is set when the terminal can't draw Unicode borders and icons (see setupConsole)
+	asciiConsole bool
+)
+
+// asciiBorder draws boxes with plain ASCII for consoles without box-drawing characters
+var asciiBorder = lipgloss.Border{
+	Top: "-", Bottom: "-", Left: "|", Right: "|",
+	TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	MiddleLeft: "+", MiddleRight: "+", Middle: "+", MiddleTop: "+", MiddleBottom: "+",
+}
+
+// setupConsole switches to ASCII borders, markers and icons on legacy Windows
+// consoles and non-UTF-8 code pages (or with --ascii)
+func setupConsole() {
+	asciiConsole = forceASCII || legacyConsole()
+	if !asciiConsole {
+		return
+	}
+	logger.Debug("using ascii fallback for the console")
+	baseTableStyle = baseTableStyle.BorderStyle(asciiBorder)
+	paletteStyle = paletteStyle.BorderStyle(asciiBorder)
+	confirmStyle = confirmStyle.BorderStyle(asciiBorder)
+	unreadMarker, bookmarkMarker, searchMarker, pinnedMarker = "* ", "+ ", "> ", "^ "
+	queuedMarker = "~"
+}
+
+// tableBorder is the border under the table headers
+func tableBorder() lipgloss.Border {
+	if asciiConsole {
+		return asciiBorder
+	}
+	return lipgloss.NormalBorder()
+}
+
+// loadingSpinner is the spinner shown while fetching
+func loadingSpinner() spinner.Spinner {
+	if asciiConsole {
+		return spinner.Line
+	}
+	return spinner.Dot
+}
+
+// consoleText drops the Nerd Font icons and emoji from s on ASCII consoles
+func consoleText(s string) string {
+	if asciiConsole {
+		return stripIcons(s)
+	}
+	return s
+}
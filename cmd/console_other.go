@@ -0,0 +1,8 @@
+//go:build !windows
+
+package cmd
+
+// legacyConsole reports whether the terminal can't draw Unicode, which is only a problem on Windows
+func legacyConsole() bool {
+	return false
+}
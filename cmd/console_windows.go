@@ -0,0 +1,33 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// utf8CodePage is the Windows code page number of UTF-8
+const utf8CodePage = 65001
+
+// legacyConsole reports whether we're in a legacy console (conhost without VT
+// processing, e.g. cmd.exe before Windows 10) or one using a non-UTF-8 code page
+func legacyConsole() bool {
+	if os.Getenv("WT_SESSION") != "" {
+		return false // Windows Terminal
+	}
+	if cp, err := windows.GetConsoleOutputCP(); err == nil && cp != 0 && cp != utf8CodePage {
+		return true
+	}
+	stdout := windows.Handle(os.Stdout.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(stdout, &mode); err != nil {
+		return false // not a console (e.g. mintty or a pipe)
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return false
+	}
+	// Consoles that predate VT processing refuse to enable it
+	return windows.SetConsoleMode(stdout, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) != nil
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"html/template"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	digestSince string
+	digestEmail bool
+)
+
+// digestRepo is a repository's events in the digest
+type digestRepo struct {
+	Name   string
+	URL    string
+	Events []digestEvent
+}
+
+// digestEvent is a single event in the digest
+type digestEvent struct {
+	User        string
+	Type        string
+	Description string
+	URL         string
+	When        string
+}
+
+// digest is the activity of the tracked users over a time window
+type digest struct {
+	Users []string
+	Since time.Time
+	Total int
+	Repos []digestRepo
+}
+
+var digestTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{"version": version}).Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; color: #24292f;">
+<h2>GitHub activity for {{range $i, $u := .Users}}{{if $i}}, {{end}}{{$u}}{{end}}</h2>
+<p style="color: #57606a;">{{.Total}} events since {{.Since.Format "Mon Jan 2 2006"}}</p>
+{{range .Repos}}
+<h3><a href="{{.URL}}" style="color: #0969da; text-decoration: none;">{{.Name}}</a> <span style="color: #57606a; font-weight: normal;">({{len .Events}})</span></h3>
+<ul>
+{{- range .Events}}
+<li>{{if .URL}}<a href="{{.URL}}" style="color: #24292f;">{{.Description}}</a>{{else}}{{.Description}}{{end}} <span style="color: #57606a;">— {{.User}}, {{.Type}}, {{.When}}</span></li>
+{{- end}}
+</ul>
+{{end}}
+<p style="color: #57606a; font-size: small;">Sent by gitfamous {{version}}</p>
+</body>
+</html>
+`))
+
+// buildDigest groups the users' events by repository, busiest first, with each
+// repository's events merged across users in the --sort order
+func buildDigest(since time.Time, feeds map[string][]eventItem, webURL, by string) digest {
+	d := digest{Since: since}
+	type userItem struct {
+		user string
+		item eventItem
+	}
+	var all []userItem
+	for user, items := range feeds {
+		d.Users = append(d.Users, user)
+		for _, item := range items {
+			all = append(all, userItem{user, item})
+		}
+	}
+	slices.SortFunc(all, func(a, b userItem) int {
+		return cmp.Or(compareEvents(a.item, b.item, by), strings.Compare(a.user, b.user))
+	})
+	repos := make(map[string]*digestRepo)
+	for _, ui := range all {
+		repo, ok := repos[ui.item.Repository.Name]
+		if !ok {
+			repo = &digestRepo{Name: ui.item.Repository.Name, URL: webURL + "/" + ui.item.Repository.Name}
+			repos[ui.item.Repository.Name] = repo
+		}
+		repo.Events = append(repo.Events, digestEvent{
+			User:        ui.user,
+			Type:        strings.TrimSuffix(ui.item.Type, "Event"),
+			Description: strings.TrimSpace(ui.item.Description),
+			URL:         eventURL(ui.item, webURL),
+			When:        humanTime(ui.item.CreatedAt),
+		})
+		d.Total++
+	}
+	slices.Sort(d.Users)
+	for _, repo := range repos {
+		d.Repos = append(d.Repos, *repo)
+	}
+	slices.SortFunc(d.Repos, func(a, b digestRepo) int {
+		if n := len(b.Events) - len(a.Events); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return d
+}
+
+// sendDigest emails the rendered digest through the configured SMTP server
+func sendDigest(conf *SMTP, subject, html string) error {
+	if conf == nil || conf.Host == "" {
+		return fmt.Errorf("--email requires an smtp section in the config file")
+	}
+	if conf.From == "" || len(conf.To) == 0 {
+		return fmt.Errorf("smtp: from and to are required")
+	}
+	port := conf.Port
+	if port == 0 {
+		port = 587
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", conf.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(conf.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	msg.WriteString(html)
+
+	var auth smtp.Auth
+	if conf.Username != "" {
+		auth = smtp.PlainAuth("", conf.Username, os.ExpandEnv(conf.Password), conf.Host)
+	}
+	addr := net.JoinHostPort(conf.Host, strconv.Itoa(port))
+	if err := smtp.SendMail(addr, auth, conf.From, conf.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send digest: %v", err)
+	}
+	return nil
+}
+
+var digestCmd = &cobra.Command{
+	Use:   "digest [username...]",
+	Short: "Summarize the users' recent activity as an HTML email",
+	Long: `Summarize the users' activity over a time window (grouped by repository) as HTML.
+
+The digest is written to stdout, or sent with --email through the smtp settings
+in the config file, e.g. from a Monday morning cron job:
+
+  0 9 * * 1  gitfamous digest --since 1w --email`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conf, users, err := setupSubcommand(args)
+		if err != nil {
+			return err
+		}
+		sinceBound, err := parseTimeBound(digestSince)
+		if err != nil {
+			return fmt.Errorf("failed to parse --since: %v", err)
+		}
+		if len(filterTypes) == 0 {
+			filterTypes = conf.Filter
+		}
+		filter, err := parseFilterTypes(filterTypes)
+		if err != nil {
+			return err
+		}
+		if err := checkSort(sortBy); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		opts := fetchOptions{Since: sinceBound, FilterTypes: filter, PerPage: 100}
+		feeds := make(map[string][]eventItem)
+		webURL := "https://" + defaultHost
+		for _, user := range users {
+			client, err := newClient(user.Token, user.Host)
+			if err != nil {
+				return err
+			}
+			if user.Host != "" {
+				webURL = "https://" + user.Host
+			}
+			opts.Host = user.Host
+			feed, err := fetchEvents(ctx, githubAPI{client}, user.Name, opts)
+			if err != nil {
+				if strings.HasPrefix(err.Error(), "no events found") {
+					continue
+				}
+				return err
+			}
+			feeds[user.Name] = feed.Items
+		}
+
+		d := buildDigest(sinceBound.Time(), feeds, webURL, sortBy)
+		var out bytes.Buffer
+		if err := digestTemplate.Execute(&out, d); err != nil {
+			return err
+		}
+		if !digestEmail {
+			_, err := os.Stdout.Write(out.Bytes())
+			return err
+		}
+		subject := fmt.Sprintf("gitfamous digest: %d events since %s", d.Total, d.Since.Format("Jan 2"))
+		if err := sendDigest(conf.SMTP, subject, out.String()); err != nil {
+			return err
+		}
+		logger.Info("sent digest", "to", strings.Join(conf.SMTP.To, ", "), "events", d.Total)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(digestCmd)
+	digestCmd.Flags().StringVarP(&digestSince, "since", "s", "1w", "Time window of the digest (e.g. 1d, 1w, 2024-01-01)")
+	digestCmd.Flags().BoolVar(&digestEmail, "email", false, "Email the digest using the smtp settings in the config file")
+	digestCmd.Flags().StringSliceVarP(&filterTypes, "filter", "f", nil, "Comma-separated list of event types or aliases to include")
+	digestCmd.Flags().StringVar(&sortBy, "sort", "date", "Order each repository's events by date, type or actor")
+	digestCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	digestCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	digestCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	digestCmd.RegisterFlagCompletionFunc("filter", completeFilter)
+	digestCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(sortModes, cobra.ShellCompDirectiveNoFileComp))
+	digestCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
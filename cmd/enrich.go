@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v66/github"
+)
+
+var (
+	// enrich fetches the diff stats of pushes and pull requests (--enrich)
+	enrich bool
+	// ciStatusFlag fetches the CI status of pushes and pull requests (--ci)
+	ciStatusFlag bool
+)
+
+// enrichWorkers is how many enrichment requests run at once
+const enrichWorkers = 4
+
+// zeroSHA is the "before" of a push that created its branch
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// diffStats is the size of a push or pull request
+type diffStats struct {
+	Additions int
+	Deletions int
+	Files     int
+}
+
+func (s diffStats) String() string {
+	minus := "−"
+	if asciiConsole {
+		minus = "-"
+	}
+	return fmt.Sprintf("+%d %s%d", s.Additions, minus, s.Deletions)
+}
+
+// diffStatsCache keeps the stats across refreshes, keyed by repo and commit range or PR
+var diffStatsCache sync.Map
+
+// ciStatus is the combined result of a commit's check runs and statuses
+type ciStatus string
+
+const (
+	ciSuccess ciStatus = "success"
+	ciFailure ciStatus = "failure"
+	ciPending ciStatus = "pending"
+)
+
+// icon is the indicator shown after the description
+func (s ciStatus) icon() string {
+	icons := map[ciStatus][2]string{
+		ciSuccess: {"✓", "[ok]"},
+		ciFailure: {"✗", "[failed]"},
+		ciPending: {"●", "[pending]"},
+	}
+	if asciiConsole {
+		return icons[s][1]
+	}
+	return icons[s][0]
+}
+
+// ciStatusCache keeps finished CI results across refreshes, keyed by repo and SHA
+var ciStatusCache sync.Map
+
+// enrichEvents adds the diff stats (--enrich) and CI status (--ci) of pushes and
+// pull requests to their descriptions
+func enrichEvents(ctx context.Context, api eventsAPI, items []eventItem, opts fetchOptions) {
+	sem := make(chan struct{}, enrichWorkers)
+	var wg sync.WaitGroup
+	for i := range items {
+		item := &items[i]
+		if item.Event == nil || item.Coalesced > 1 {
+			continue
+		}
+		if item.Type != "PushEvent" && item.Type != "PullRequestEvent" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if opts.Enrich && item.Stats == nil {
+				stats, err := fetchDiffStats(ctx, api, item.Event)
+				if err != nil {
+					logger.Debug("fetching diff stats", "repo", item.Repository.Name, "type", item.Type, "error", err)
+				} else {
+					item.Stats = stats
+					item.Description += " " + stats.String()
+				}
+			}
+			if opts.CIStatus && item.CI == "" {
+				status, err := fetchCIStatus(ctx, api, item.Event)
+				if err != nil {
+					logger.Debug("fetching ci status", "repo", item.Repository.Name, "type", item.Type, "error", err)
+				} else if status != "" {
+					item.CI = status
+					item.Description += " " + status.icon()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// fetchDiffStats returns the additions, deletions and files changed by a push or pull request
+func fetchDiffStats(ctx context.Context, api eventsAPI, event *github.Event) (*diffStats, error) {
+	owner, repo, ok := strings.Cut(event.GetRepo().GetName(), "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name %s", event.GetRepo().GetName())
+	}
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return nil, err
+	}
+	switch p := payload.(type) {
+	case *github.PushEvent:
+		key := fmt.Sprintf("%s@%s...%s", event.GetRepo().GetName(), p.GetBefore(), p.GetHead())
+		if stats, ok := diffStatsCache.Load(key); ok {
+			return stats.(*diffStats), nil
+		}
+		var files []*github.CommitFile
+		if p.GetBefore() == "" || p.GetBefore() == zeroSHA {
+			// A new branch: only count the head commit
+			commit, _, err := api.GetCommit(ctx, owner, repo, p.GetHead(), nil)
+			if err != nil {
+				return nil, err
+			}
+			files = commit.Files
+		} else {
+			comparison, _, err := api.CompareCommits(ctx, owner, repo, p.GetBefore(), p.GetHead(), &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return nil, err
+			}
+			files = comparison.Files
+		}
+		stats := &diffStats{Files: len(files)}
+		for _, f := range files {
+			stats.Additions += f.GetAdditions()
+			stats.Deletions += f.GetDeletions()
+		}
+		diffStatsCache.Store(key, stats)
+		return stats, nil
+	case *github.PullRequestEvent:
+		pr := p.GetPullRequest()
+		if pr.Additions == nil {
+			// Trimmed payloads leave out the stats
+			key := fmt.Sprintf("%s#%d@%s", event.GetRepo().GetName(), p.GetNumber(), pr.GetHead().GetSHA())
+			if stats, ok := diffStatsCache.Load(key); ok {
+				return stats.(*diffStats), nil
+			}
+			if pr, _, err = api.GetPullRequest(ctx, owner, repo, p.GetNumber()); err != nil {
+				return nil, err
+			}
+			stats := &diffStats{Additions: pr.GetAdditions(), Deletions: pr.GetDeletions(), Files: pr.GetChangedFiles()}
+			diffStatsCache.Store(key, stats)
+			return stats, nil
+		}
+		return &diffStats{Additions: pr.GetAdditions(), Deletions: pr.GetDeletions(), Files: pr.GetChangedFiles()}, nil
+	}
+	return nil, fmt.Errorf("no diff stats for %s", event.GetType())
+}
+
+// fetchCIStatus returns the combined check status of the head commit of a push or pull request
+func fetchCIStatus(ctx context.Context, api eventsAPI, event *github.Event) (ciStatus, error) {
+	owner, repo, ok := strings.Cut(event.GetRepo().GetName(), "/")
+	if !ok {
+		return "", fmt.Errorf("invalid repository name %s", event.GetRepo().GetName())
+	}
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return "", err
+	}
+	var sha string
+	switch p := payload.(type) {
+	case *github.PushEvent:
+		sha = p.GetHead()
+	case *github.PullRequestEvent:
+		if sha = p.GetPullRequest().GetHead().GetSHA(); sha == "" {
+			pr, _, err := api.GetPullRequest(ctx, owner, repo, p.GetNumber())
+			if err != nil {
+				return "", err
+			}
+			sha = pr.GetHead().GetSHA()
+		}
+	}
+	if sha == "" {
+		return "", fmt.Errorf("no head commit for %s", event.GetType())
+	}
+	key := event.GetRepo().GetName() + "@" + sha
+	if status, ok := ciStatusCache.Load(key); ok {
+		return status.(ciStatus), nil
+	}
+	runs, _, err := api.ListCheckRunsForRef(ctx, owner, repo, sha, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}})
+	if err != nil {
+		return "", err
+	}
+	combined, _, err := api.GetCombinedStatus(ctx, owner, repo, sha, nil)
+	if err != nil {
+		return "", err
+	}
+	status := combineCIStatus(runs.CheckRuns, combined)
+	if status == ciSuccess || status == ciFailure {
+		ciStatusCache.Store(key, status)
+	}
+	return status, nil
+}
+
+// combineCIStatus reduces check runs and commit statuses to failure, pending or
+// success (in that order of precedence), or "" when the commit has no CI
+func combineCIStatus(runs []*github.CheckRun, combined *github.CombinedStatus) ciStatus {
+	var states []ciStatus
+	for _, run := range runs {
+		if run.GetStatus() != "completed" {
+			states = append(states, ciPending)
+			continue
+		}
+		switch run.GetConclusion() {
+		case "success", "neutral", "skipped":
+			states = append(states, ciSuccess)
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			states = append(states, ciFailure)
+		}
+	}
+	if combined.GetTotalCount() > 0 {
+		switch combined.GetState() {
+		case "success":
+			states = append(states, ciSuccess)
+		case "failure", "error":
+			states = append(states, ciFailure)
+		default:
+			states = append(states, ciPending)
+		}
+	}
+	switch {
+	case len(states) == 0:
+		return ""
+	case slices.Contains(states, ciFailure):
+		return ciFailure
+	case slices.Contains(states, ciPending):
+		return ciPending
+	}
+	return ciSuccess
+}
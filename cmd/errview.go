@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v66/github"
+)
+
+var errorTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("204"))
+
+// describeFetchError turns a fetch error into a short title and a hint on how to fix it
+func describeFetchError(err error, username string) (title, hint string) {
+	var rateErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	var errResp *github.ErrorResponse
+	var unknownErr *unknownUserError
+	switch {
+	case errors.Is(err, errFetchCancelled):
+		return "Cancelled", "Fetching events was cancelled."
+	case errors.As(err, &unknownErr):
+		hint = fmt.Sprintf("GitHub user %q does not exist.", username)
+		if len(unknownErr.suggestions) > 0 {
+			var options []string
+			for i, s := range unknownErr.suggestions {
+				options = append(options, fmt.Sprintf("[%d] %s", i+1, s))
+			}
+			hint += "\n\nDid you mean " + strings.Join(options, ", ") + "?"
+		}
+		return "Unknown user", hint
+	case errors.As(err, &rateErr):
+		reset := rateErr.Rate.Reset.Time
+		return "Rate limited", fmt.Sprintf("API rate limit exceeded (%d requests/hour), resets %s at %s.",
+			rateErr.Rate.Limit, humanTime(reset), reset.Local().Format(time.Kitchen))
+	case errors.As(err, &abuseErr):
+		wait := "in a little while"
+		if d := abuseErr.GetRetryAfter(); d > 0 {
+			wait = "in " + d.Round(time.Second).String()
+		}
+		return "Rate limited", "Secondary rate limit hit, try again " + wait + "."
+	case errors.As(err, &errResp):
+		switch errResp.Response.StatusCode {
+		case http.StatusNotFound:
+			return "Unknown user", fmt.Sprintf("GitHub user %q does not exist.", username)
+		case http.StatusUnauthorized:
+			return "Bad credentials", "The API token was rejected; check --api, $GITHUB_TOKEN or the token in your config."
+		case http.StatusForbidden:
+			return "Forbidden", errResp.Message
+		}
+		return fmt.Sprintf("GitHub API error (HTTP %d)", errResp.Response.StatusCode), errResp.Message
+	case isNetworkError(err):
+		return "Network error", err.Error()
+	case strings.HasPrefix(err.Error(), "no events found"):
+		return "No events", err.Error()
+	}
+	return "Error", err.Error()
+}
+
+// updateError handles keys on the error screen
+func (m model) updateError(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r":
+		m.err = nil
+		return m, m.refetch()
+	case "u":
+		if m.readOnly {
+			// ssh sessions only see the users they were given
+			return m, nil
+		}
+		return m, m.openUserPrompt()
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	}
+	if m.readOnly {
+		return m, nil
+	}
+	// Pick one of the suggested users
+	var unknownErr *unknownUserError
+	if errors.As(m.err, &unknownErr) {
+		if n, err := strconv.Atoi(msg.String()); err == nil && n >= 1 && n <= len(unknownErr.suggestions) {
+			return m, m.switchUser(unknownErr.suggestions[n-1])
+		}
+	}
+	return m, nil
+}
+
+func (m model) errorView() string {
+	title, hint := describeFetchError(m.err, m.username)
+	var sb strings.Builder
+	sb.WriteString(errorTitleStyle.Render(title) + "\n\n")
+	sb.WriteString(lipgloss.NewStyle().Width(min(m.termWidth()-4, 80)).Render(hint) + "\n\n")
+	if m.showUserPrompt {
+		sb.WriteString(m.userPromptView() + "\n")
+	} else {
+		hints := "r retry • u change user • q quit"
+		if m.readOnly {
+			hints = "r retry • q quit"
+		}
+		var unknownErr *unknownUserError
+		if errors.As(m.err, &unknownErr) && len(unknownErr.suggestions) > 0 && !m.readOnly {
+			hints = fmt.Sprintf("1-%d switch to suggestion • ", len(unknownErr.suggestions)) + hints
+		}
+		sb.WriteString(lipgloss.NewStyle().Foreground(currentTheme.Dim).Render(hints) + "\n")
+	}
+	return lipgloss.NewStyle().Padding(1, 2).Render(sb.String())
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// estimate prints the API cost of a run instead of fetching (--estimate)
+var estimate bool
+
+// eventsAPILimit is how many events the events API serves at most
+const eventsAPILimit = 300
+
+// requestEstimate is a range of API requests with what they're for
+type requestEstimate struct {
+	min, max int
+	parts    []string
+}
+
+func (e *requestEstimate) add(min, max int, what string) {
+	e.min += min
+	e.max += max
+	e.parts = append(e.parts, what)
+}
+
+func (e requestEstimate) String() string {
+	if e.min == e.max {
+		return fmt.Sprintf("%d requests", e.max)
+	}
+	return fmt.Sprintf("%d-%d requests", e.min, e.max)
+}
+
+// estimateRequests works out how many API requests fetching a user's first screen
+// of events with opts takes, following the pagination of listEvents
+func estimateRequests(opts fetchOptions) requestEstimate {
+	var e requestEstimate
+	e.add(1, 1, "user check")
+	if opts.IncludePrivate {
+		e.add(1, 1, "token scope check")
+	}
+
+	count := cmp.Or(opts.Count, lazyCount)
+	perPage := cmp.Or(opts.PerPage, 30)
+	if len(opts.FilterTypes) == 0 && opts.Expr == nil && opts.Until.IsZero() {
+		perPage = min(perPage, count)
+	}
+	maxPages := (eventsAPILimit + perPage - 1) / perPage
+	pages := min((count+perPage-1)/perPage, maxPages)
+	minPages, maxNeeded := pages, pages
+	if len(opts.FilterTypes) > 0 || opts.Expr != nil || !opts.Until.IsZero() ||
+		len(opts.IgnoreUsers) > 0 || opts.Grep != nil || opts.GrepV != nil {
+		// Filtered out events have to be made up for with more pages
+		maxNeeded = maxPages
+	}
+	if !opts.Since.IsZero() {
+		// The --since cutoff may come before count events
+		minPages = 1
+	}
+	pagesOf := fmt.Sprintf("%d", maxNeeded)
+	if minPages != maxNeeded {
+		pagesOf = fmt.Sprintf("%d-%d", minPages, maxNeeded)
+	}
+	e.add(minPages, maxNeeded, fmt.Sprintf("%s pages of %d events", pagesOf, perPage))
+
+	if opts.Enrich {
+		e.add(0, count, "diff stats")
+	}
+	if opts.CIStatus {
+		e.add(0, 3*count, "CI status")
+	}
+	return e
+}
+
+// printEstimate prints the plan of fetching the users' events with opts and whether
+// the tokens have enough of their rate limit left, without fetching anything.
+// It reports whether the rate limit suffices.
+func printEstimate(w io.Writer, users []User, opts fetchOptions) (bool, error) {
+	switch {
+	case opts.Offline:
+		fmt.Fprintln(w, "No API requests: --offline shows cached events")
+		return true, nil
+	case opts.Replay != nil:
+		fmt.Fprintln(w, "No API requests: --replay serves events from the fixture")
+		return true, nil
+	}
+
+	type budget struct {
+		host, owner string
+		token       string
+		total       requestEstimate
+	}
+	var budgets []*budget
+	var total requestEstimate
+	for _, user := range users {
+		e := estimateRequests(opts)
+		fmt.Fprintf(w, "%-20s %s (%s)\n", user.Name, e, strings.Join(e.parts, ", "))
+		total.add(e.min, e.max, "")
+
+		var b *budget
+		for _, other := range budgets {
+			if other.token == user.Token && other.host == user.Host {
+				b = other
+			}
+		}
+		if b == nil {
+			b = &budget{host: cmp.Or(user.Host, "github.com"), owner: user.Name, token: user.Token}
+			budgets = append(budgets, b)
+		}
+		b.total.add(e.min, e.max, "")
+	}
+	if len(users) > 1 {
+		fmt.Fprintf(w, "%-20s %s\n", "total", total)
+	}
+	fmt.Fprintln(w)
+
+	enough := true
+	for _, b := range budgets {
+		client, err := newClient(b.token, b.host)
+		if err != nil {
+			return false, err
+		}
+		// Checking the rate limit doesn't count against it
+		limits, _, err := client.RateLimit.Get(context.Background())
+		if err != nil {
+			return false, fmt.Errorf("failed to get the rate limit: %v", err)
+		}
+		core := limits.GetCore()
+		token := "token of " + b.owner
+		if b.token == "" {
+			token = "no token"
+		}
+		verdict := "enough"
+		switch {
+		case b.total.min > core.Remaining:
+			verdict = "not enough"
+			enough = false
+		case b.total.max > core.Remaining:
+			verdict = "may not be enough"
+		}
+		fmt.Fprintf(w, "Rate limit (%s on %s): %s of %s left, resets in %s: %s\n", token, b.host,
+			humanize.Comma(int64(core.Remaining)), humanize.Comma(int64(core.Limit)),
+			time.Until(core.Reset.Time).Round(time.Minute), verdict)
+	}
+	return enough, nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/google/go-github/v66/github"
+)
+
+// exprEnv is what --expr expressions are evaluated against for each event, e.g.
+//
+//	type == "PushEvent" && repo =~ "ipsw"
+//	action in ["opened", "closed"] && created_at > now() - duration("24h")
+type exprEnv struct {
+	Type        string         `expr:"type"`
+	Repo        string         `expr:"repo"`
+	Actor       string         `expr:"actor"`
+	Description string         `expr:"description"`
+	Action      string         `expr:"action"` // payload action (opened, closed, ...)
+	Ref         string         `expr:"ref"`    // payload ref (branch or tag)
+	Public      bool           `expr:"public"`
+	CreatedAt   time.Time      `expr:"created_at"`
+	Payload     map[string]any `expr:"payload"` // the raw event payload
+}
+
+// compileExpr compiles an --expr expression, which must evaluate to a bool
+func compileExpr(source string) (*vm.Program, error) {
+	program, err := expr.Compile(rewriteMatchOperator(source), expr.Env(exprEnv{}), expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("invalid --expr: %v", err)
+	}
+	return program, nil
+}
+
+// rewriteMatchOperator turns the =~ shorthand into expr's matches operator (outside string literals)
+func rewriteMatchOperator(source string) string {
+	var sb strings.Builder
+	var quote rune
+	runes := []rune(source)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == '\\' && i+1 < len(runes) {
+				sb.WriteRune(r)
+				i++
+				r = runes[i]
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '=' && i+1 < len(runes) && runes[i+1] == '~':
+			sb.WriteString(" matches ")
+			i++
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// matchExpr reports whether event satisfies the compiled --expr program
+func matchExpr(program *vm.Program, event *github.Event) bool {
+	env := exprEnv{
+		Type:        event.GetType(),
+		Repo:        event.GetRepo().GetName(),
+		Actor:       event.GetActor().GetLogin(),
+		Description: getEventDescription(event),
+		Public:      event.GetPublic(),
+		CreatedAt:   event.GetCreatedAt().Time,
+	}
+	if err := json.Unmarshal(event.GetRawPayload(), &env.Payload); err == nil {
+		env.Action, _ = env.Payload["action"].(string)
+		env.Ref, _ = env.Payload["ref"].(string)
+	}
+	out, err := expr.Run(program, env)
+	if err != nil {
+		logger.Debug("evaluating --expr", "event", event.GetID(), "error", err)
+		return false
+	}
+	matched, _ := out.(bool)
+	return matched
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fansSince string
+	fansRepos int
+)
+
+// fan is someone who starred or forked one of your repositories
+type fan struct {
+	Login  string
+	Action string // starred or forked
+	Repo   string
+	At     time.Time
+}
+
+// popularRepos returns the token user's repositories of the visibility and
+// affiliation, most starred and forked first
+func popularRepos(ctx context.Context, client *github.Client, visibility, affiliation string) ([]*github.Repository, error) {
+	opts := &github.RepositoryListByAuthenticatedUserOptions{
+		Visibility:  visibility,
+		Affiliation: affiliation,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var repos []*github.Repository
+	for {
+		page, resp, err := client.Repositories.ListByAuthenticatedUser(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	slices.SortStableFunc(repos, func(a, b *github.Repository) int {
+		return cmp.Compare(b.GetStargazersCount()+b.GetForksCount(), a.GetStargazersCount()+a.GetForksCount())
+	})
+	return repos, nil
+}
+
+// recentStargazers returns who starred repo since, from the last pages of its
+// stargazers (which are listed oldest first)
+func recentStargazers(ctx context.Context, client *github.Client, repo *github.Repository, since time.Time) ([]fan, error) {
+	const perPage = 100
+	var fans []fan
+	for page := (repo.GetStargazersCount() + perPage - 1) / perPage; page > 0; page-- {
+		stargazers, _, err := client.Activity.ListStargazers(ctx, repo.GetOwner().GetLogin(), repo.GetName(), &github.ListOptions{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, err
+		}
+		older := false
+		for _, s := range stargazers {
+			if s.GetStarredAt().Before(since) {
+				older = true
+				continue
+			}
+			fans = append(fans, fan{Login: s.GetUser().GetLogin(), Action: "starred", Repo: repo.GetFullName(), At: s.GetStarredAt().Time})
+		}
+		if older {
+			break
+		}
+	}
+	return fans, nil
+}
+
+// recentForkers returns who forked repo since
+func recentForkers(ctx context.Context, client *github.Client, repo *github.Repository, since time.Time) ([]fan, error) {
+	opts := &github.RepositoryListForksOptions{Sort: "newest", ListOptions: github.ListOptions{PerPage: 100}}
+	var fans []fan
+	for {
+		forks, resp, err := client.Repositories.ListForks(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, fork := range forks {
+			if fork.GetCreatedAt().Before(since) {
+				return fans, nil
+			}
+			fans = append(fans, fan{Login: fork.GetOwner().GetLogin(), Action: "forked", Repo: repo.GetFullName(), At: fork.GetCreatedAt().Time})
+		}
+		if resp.NextPage == 0 {
+			return fans, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// writeFans prints the fans newest first with a summary
+func writeFans(w io.Writer, login, window string, repos int, fans []fan) {
+	fmt.Fprintf(w, "Fans of %s in the last %s (%d most popular repos)\n\n", login, window, repos)
+	if len(fans) == 0 {
+		fmt.Fprintln(w, "Nobody starred or forked your repos, yet")
+		return
+	}
+	slices.SortFunc(fans, func(a, b fan) int { return b.At.Compare(a.At) })
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	people := make(map[string]bool)
+	var stars, forks int
+	for _, f := range fans {
+		fmt.Fprintf(tw, "  %s\t%s\t%s %s\n", humanTime(f.At), f.Login, f.Action, f.Repo)
+		people[f.Login] = true
+		if f.Action == "starred" {
+			stars++
+		} else {
+			forks++
+		}
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\n%d stars and %d forks from %d people\n", stars, forks, len(people))
+}
+
+var fansCmd = &cobra.Command{
+	Use:   "fans",
+	Short: "Show who recently starred or forked your repositories",
+	Long: `List the people who starred or forked the public repositories of the token's
+user recently, newest first: who is noticing your work right now.
+
+Only the most popular repositories are checked (--repos) to save API requests.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sinceBound, err := parseTimeBound(fansSince)
+		if err != nil {
+			return err
+		}
+		if sinceBound.IsZero() {
+			return fmt.Errorf("--since is required")
+		}
+		conf, _, err := setupSubcommand(nil)
+		if err != nil {
+			return err
+		}
+		user, err := meUser(conf)
+		if err != nil {
+			return err
+		}
+		client, err := newClient(user.Token, user.Host)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		repos, err := popularRepos(ctx, client, "public", "owner")
+		if err != nil {
+			return fmt.Errorf("failed to list repositories: %v", err)
+		}
+		repos = slices.DeleteFunc(repos, func(r *github.Repository) bool {
+			return r.GetStargazersCount() == 0 && r.GetForksCount() == 0
+		})
+		repos = repos[:min(len(repos), fansRepos)]
+
+		since := sinceBound.Time()
+		var fans []fan
+		for _, repo := range repos {
+			stargazers, err := recentStargazers(ctx, client, repo, since)
+			if err != nil {
+				return fmt.Errorf("failed to list stargazers of %s: %v", repo.GetFullName(), err)
+			}
+			forkers, err := recentForkers(ctx, client, repo, since)
+			if err != nil {
+				return fmt.Errorf("failed to list forks of %s: %v", repo.GetFullName(), err)
+			}
+			fans = append(fans, stargazers...)
+			fans = append(fans, forkers...)
+		}
+		writeFans(os.Stdout, user.Name, fansSince, len(repos), fans)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fansCmd)
+	fansCmd.Flags().StringVarP(&fansSince, "since", "s", "1w", "How far back to look (e.g. 24h, 1w, 2024-01-01)")
+	fansCmd.Flags().IntVar(&fansRepos, "repos", 10, "Number of repositories to check, most starred first")
+	fansCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	fansCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	fansCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	fansCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
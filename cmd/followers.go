@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-github/v66/github"
+	"github.com/spf13/cobra"
+)
+
+// followersListLimit is how many followers are listed to tell who came and went;
+// above it only the count is tracked to save API requests
+const followersListLimit = 3000
+
+// followerCount is the follower count of a user at one run
+type followerCount struct {
+	Time  time.Time `json:"time"`
+	Count int       `json:"count"`
+}
+
+// followersArchive is the on-disk record of a user's followers: who they were at
+// the last run and how their count changed over time
+type followersArchive struct {
+	Followers []string        `json:"followers,omitempty"`
+	History   []followerCount `json:"history"`
+}
+
+// followerChange is how a user's followers changed since the previous run
+type followerChange struct {
+	Count    int
+	Previous *followerCount // nil on the first run
+	Gained   []string
+	Lost     []string
+	Listed   bool // whether Gained and Lost are known
+	History  []followerCount
+}
+
+func followersPath(username string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "followers", strings.ToLower(username)+".json"), nil
+}
+
+func loadFollowersArchive(username string) (followersArchive, error) {
+	var archive followersArchive
+	fname, err := followersPath(username)
+	if err != nil {
+		return archive, err
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return archive, nil
+		}
+		return archive, err
+	}
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return archive, fmt.Errorf("failed to parse followers archive for user %s: %v", username, err)
+	}
+	return archive, nil
+}
+
+func saveFollowersArchive(username string, archive followersArchive) error {
+	fname, err := followersPath(username)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fname, data, 0o600)
+}
+
+// listFollowers returns the logins of all of the user's followers
+func listFollowers(ctx context.Context, client *github.Client, username string) ([]string, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var logins []string
+	for {
+		followers, resp, err := client.Users.ListFollowers(ctx, username, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range followers {
+			logins = append(logins, f.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			return logins, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// trackFollowers records the user's current followers in their archive and returns
+// how they changed since the previous run
+func trackFollowers(ctx context.Context, client *github.Client, username string) (followerChange, error) {
+	user, _, err := client.Users.Get(ctx, username)
+	if err != nil {
+		return followerChange{}, fmt.Errorf("failed to get user %s: %v", username, err)
+	}
+	archive, err := loadFollowersArchive(username)
+	if err != nil {
+		return followerChange{}, err
+	}
+	change := followerChange{Count: user.GetFollowers()}
+	if n := len(archive.History); n > 0 {
+		change.Previous = &archive.History[n-1]
+	}
+
+	var followers []string
+	if change.Count <= followersListLimit {
+		followers, err = listFollowers(ctx, client, username)
+		if err != nil {
+			return followerChange{}, fmt.Errorf("failed to list followers of %s: %v", username, err)
+		}
+		slices.Sort(followers)
+		// The followers of the previous run are only comparable if they were listed
+		if change.Previous != nil && (archive.Followers != nil || change.Previous.Count == 0) {
+			change.Listed = true
+			for _, login := range followers {
+				if _, found := slices.BinarySearch(archive.Followers, login); !found {
+					change.Gained = append(change.Gained, login)
+				}
+			}
+			for _, login := range archive.Followers {
+				if _, found := slices.BinarySearch(followers, login); !found {
+					change.Lost = append(change.Lost, login)
+				}
+			}
+		}
+	}
+
+	// Only changes are kept so the history stays small when run often
+	if change.Previous == nil || change.Previous.Count != change.Count {
+		archive.History = append(archive.History, followerCount{Time: time.Now(), Count: change.Count})
+	}
+	archive.Followers = followers
+	if err := saveFollowersArchive(username, archive); err != nil {
+		return followerChange{}, fmt.Errorf("failed to save followers archive: %v", err)
+	}
+	change.History = archive.History
+	return change, nil
+}
+
+// writeFollowers prints the follower count of the user with who they gained and
+// lost since the previous run and how the count went over time
+func writeFollowers(w io.Writer, username string, change followerChange) {
+	fmt.Fprintf(w, "%s: %s followers", username, humanize.Comma(int64(change.Count)))
+	if change.Previous == nil {
+		fmt.Fprintln(w, " (first run, changes show from the next one)")
+		return
+	}
+	switch diff := change.Count - change.Previous.Count; {
+	case diff > 0:
+		fmt.Fprintf(w, " (+%d since %s)\n", diff, humanTime(change.Previous.Time))
+	case diff < 0:
+		fmt.Fprintf(w, " (−%d since %s)\n", -diff, humanTime(change.Previous.Time))
+	default:
+		fmt.Fprintf(w, " (no change since %s)\n", humanTime(change.Previous.Time))
+	}
+	if len(change.Gained) > 0 {
+		fmt.Fprintf(w, "  gained: %s\n", strings.Join(change.Gained, ", "))
+	}
+	if len(change.Lost) > 0 {
+		fmt.Fprintf(w, "  lost:   %s\n", strings.Join(change.Lost, ", "))
+	}
+	if !change.Listed && change.Count > followersListLimit {
+		fmt.Fprintf(w, "  (who isn't tracked above %s followers)\n", humanize.Comma(followersListLimit))
+	}
+	if len(change.History) > 1 {
+		counts := make([]int, len(change.History))
+		for i, h := range change.History {
+			counts[i] = h.Count
+		}
+		fmt.Fprintf(w, "  history: %s %s → %s since %s\n", sparkline(counts),
+			humanize.Comma(int64(counts[0])), humanize.Comma(int64(change.Count)), humanTime(change.History[0].Time))
+	}
+}
+
+var followersCmd = &cobra.Command{
+	Use:   "followers <username>",
+	Short: "Track a user's followers between runs",
+	Long: `Show a user's follower count with who followed and unfollowed them since the
+previous run, and how the count went over time.
+
+The followers are archived locally on each run, so run it regularly (e.g. from
+a daily cron job) to build up the history.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, users, err := setupSubcommand(args)
+		if err != nil {
+			return err
+		}
+		setupConsole()
+		client, err := newClient(users[0].Token, users[0].Host)
+		if err != nil {
+			return err
+		}
+		change, err := trackFollowers(context.Background(), client, users[0].Name)
+		if err != nil {
+			return err
+		}
+		writeFollowers(os.Stdout, users[0].Name, change)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(followersCmd)
+	followersCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	followersCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	followersCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	followersCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// maxViewHistory is how many view changes can be undone
+const maxViewHistory = 50
+
+// viewState is the part of the view that can be undone: how events are sorted,
+// grouped, searched and filtered by type and which repos are muted
+type viewState struct {
+	sort        string
+	groupByRepo bool
+	typeFilter  string
+	searchQuery string
+	muted       []string
+}
+
+func (m model) viewState() viewState {
+	return viewState{
+		sort:        m.fetch.Sort,
+		groupByRepo: m.groupByRepo,
+		typeFilter:  m.typeFilter,
+		searchQuery: m.searchQuery,
+		muted:       slices.Clone(m.fetch.MutedRepos),
+	}
+}
+
+// pushView records the view before a change so it can be undone
+func (m *model) pushView() {
+	m.undoViews = append(m.undoViews, m.viewState())
+	if len(m.undoViews) > maxViewHistory {
+		m.undoViews = slices.Delete(m.undoViews, 0, 1)
+	}
+	m.redoViews = nil
+}
+
+// undoView goes back to the view before the last change
+func (m *model) undoView() {
+	if len(m.undoViews) == 0 {
+		m.status = "nothing to undo"
+		return
+	}
+	prev := m.undoViews[len(m.undoViews)-1]
+	m.undoViews = m.undoViews[:len(m.undoViews)-1]
+	m.redoViews = append(m.redoViews, m.viewState())
+	m.restoreView(prev)
+	m.status = fmt.Sprintf("undone (%d more)", len(m.undoViews))
+}
+
+// redoView reapplies the last undone change
+func (m *model) redoView() {
+	if len(m.redoViews) == 0 {
+		m.status = "nothing to redo"
+		return
+	}
+	next := m.redoViews[len(m.redoViews)-1]
+	m.redoViews = m.redoViews[:len(m.redoViews)-1]
+	m.undoViews = append(m.undoViews, m.viewState())
+	m.restoreView(next)
+	m.status = fmt.Sprintf("redone (%d more)", len(m.redoViews))
+}
+
+// restoreView applies a recorded view, keeping the cursor on the selected event
+func (m *model) restoreView(s viewState) {
+	selected := -1
+	if cursor := m.table.Cursor(); cursor >= 0 && cursor < len(m.visible) {
+		selected = m.visible[cursor]
+	}
+	if !slices.Equal(s.muted, m.fetch.MutedRepos) {
+		if err := saveMutedRepos(m.fetch.MutedRepos, s.muted); err != nil {
+			logger.Debug("saving muted repos", "error", err)
+		}
+		m.fetch.MutedRepos = s.muted
+	}
+	if s.sort != m.fetch.Sort {
+		var id string
+		if selected >= 0 && m.events[selected].Event != nil {
+			id = m.events[selected].Event.GetID()
+		}
+		m.fetch.Sort = s.sort
+		sortEvents(m.events, s.sort)
+		m.searchTexts = nil
+		selected = slices.IndexFunc(m.events, func(item eventItem) bool { return id != "" && item.Event.GetID() == id })
+	}
+	m.groupByRepo = s.groupByRepo
+	m.typeFilter = s.typeFilter
+	m.searchQuery = s.searchQuery
+	m.updateSearchMatches()
+	m.setupTable()
+	if row := slices.Index(m.visible, selected); row >= 0 {
+		m.table.SetCursor(row)
+	} else {
+		m.table.SetCursor(min(m.table.Cursor(), max(len(m.visible)-1, 0)))
+	}
+}
+
+// cycleSort sorts the events by the next --sort order
+func (m *model) cycleSort() {
+	next := sortModes[(slices.Index(sortModes, cmp.Or(m.fetch.Sort, "date"))+1)%len(sortModes)]
+	s := m.viewState()
+	s.sort = next
+	m.pushView()
+	m.restoreView(s)
+	m.status = "sorted by " + next
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// hyperlinks makes repository names and descriptions clickable with OSC 8 (--hyperlinks)
+var hyperlinks bool
+
+// supportsHyperlinks guesses whether the terminal understands OSC 8 hyperlinks
+func supportsHyperlinks() bool {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "rio":
+		return true
+	case "tmux", "Apple_Terminal":
+		return false
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true // GNOME Terminal, Tilix, ...
+	}
+	term := os.Getenv("TERM")
+	return strings.Contains(term, "kitty") || strings.Contains(term, "alacritty") || strings.Contains(term, "foot")
+}
+
+// linkRows hyperlinks the repository and description of each row in the rendered
+// table. The links can't go in the cells because the table would count the URLs
+// towards the column widths, so the rows are found by their (truncated) text.
+func (m model) linkRows(view string) string {
+	if !hyperlinks || asciiConsole || len(m.rowURLs) == 0 {
+		return view
+	}
+	cols := m.table.Columns()
+	repoCol, descCol := max(m.column("Repository"), m.column("Repo")), m.column("Description")
+	if repoCol < 0 || descCol < 0 {
+		return view
+	}
+	type rowText struct{ repo, desc, url string }
+	var rows []rowText
+	for i, row := range m.table.Rows() {
+		if i >= len(m.rowURLs) {
+			break
+		}
+		rows = append(rows, rowText{
+			repo: runewidth.Truncate(row[repoCol], cols[repoCol].Width, "…"),
+			desc: runewidth.Truncate(row[descCol], cols[descCol].Width, "…"),
+			url:  m.rowURLs[i],
+		})
+	}
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		for _, row := range rows {
+			if row.desc == "" || !strings.Contains(plain, row.repo) || !strings.Contains(plain, row.desc) {
+				continue
+			}
+			repoAt := strings.Index(line, row.repo)
+			descAt := strings.Index(line[max(repoAt, 0):], row.desc)
+			if repoAt < 0 || descAt < 0 {
+				break // split up by styling
+			}
+			descAt += repoAt
+			lines[i] = line[:repoAt] + ansi.SetHyperlink(row.url) + row.repo + ansi.ResetHyperlink() +
+				line[repoAt+len(row.repo):descAt] + ansi.SetHyperlink(row.url) + row.desc + ansi.ResetHyperlink() +
+				line[descAt+len(row.desc):]
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// keyMap defines the keybindings of the events view
+type keyMap struct {
+	table.KeyMap
+	Open        key.Binding
+	Browser     key.Binding
+	Details     key.Binding
+	Commits     key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	TimeFormat  key.Binding
+	Star        key.Binding
+	Follow      key.Binding
+	SwitchUser  key.Binding
+	Bookmark    key.Binding
+	Bookmarks   key.Binding
+	Pin         key.Binding
+	Mute        key.Binding
+	Undo        key.Binding
+	Redo        key.Binding
+	Search      key.Binding
+	NextMatch   key.Binding
+	PrevMatch   key.Binding
+	TypeFilter  key.Binding
+	Screenshot  key.Binding
+	LoadMore    key.Binding
+	Refresh     key.Binding
+	Timeline    key.Binding
+	NextTab     key.Binding
+	PrevTab     key.Binding
+	SwitchPane  key.Binding
+	Palette     key.Binding
+	Legend      key.Binding
+	Badges      key.Binding
+	Help        key.Binding
+	Quit        key.Binding
+}
+
+// ShortHelp implements the help.KeyMap interface
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.LineUp, k.LineDown, k.Open, k.Details, k.Help, k.Quit}
+}
+
+// FullHelp implements the help.KeyMap interface
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.LineUp, k.LineDown, k.GotoTop, k.GotoBottom, k.LoadMore, k.Refresh},
+		{k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown},
+		{k.Open, k.Browser, k.Details, k.Commits, k.TimeFormat, k.Timeline},
+		{k.ScrollLeft, k.ScrollRight, k.Search, k.NextMatch, k.PrevMatch, k.TypeFilter, k.Undo, k.Redo},
+		{k.Star, k.Follow, k.SwitchUser, k.Bookmark, k.Bookmarks, k.Pin, k.Mute, k.Screenshot},
+		{k.NextTab, k.PrevTab, k.SwitchPane, k.Palette, k.Legend, k.Badges, k.Help, k.Quit},
+	}
+}
+
+func defaultKeyMap() keyMap {
+	tableKeys := table.DefaultKeyMap()
+	// 'b' bookmarks events
+	tableKeys.PageUp = key.NewBinding(
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "page up"),
+	)
+	// 'u' switches users
+	tableKeys.HalfPageUp = key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("ctrl+u", "½ page up"),
+	)
+	return keyMap{
+		KeyMap: tableKeys,
+		Open: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "open in browser"),
+		),
+		Browser: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in browser"),
+		),
+		Details: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "view details"),
+		),
+		Commits: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", "push commits"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("h", "shift+left"),
+			key.WithHelp("h/⇧←", "scroll description left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("l", "shift+right"),
+			key.WithHelp("l/⇧→", "scroll description right"),
+		),
+		TimeFormat: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "toggle time format"),
+		),
+		Star: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "star/unstar repo"),
+		),
+		Follow: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "follow/unfollow user"),
+		),
+		SwitchUser: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "switch user"),
+		),
+		Bookmark: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "bookmark event"),
+		),
+		Bookmarks: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "bookmarks"),
+		),
+		Pin: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pin/unpin repo"),
+		),
+		Mute: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "mute repo"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("ctrl+z"),
+			key.WithHelp("ctrl+z", "undo view change"),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("ctrl+y", "redo view change"),
+		),
+		// Tab bindings are only enabled in multi-user mode
+		Search: key.NewBinding(
+			key.WithKeys("ctrl+f"),
+			key.WithHelp("ctrl+f", "search events"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match"),
+		),
+		TypeFilter: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "only this event type"),
+		),
+		Screenshot: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "save screenshot"),
+		),
+		LoadMore: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "load more events"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Timeline: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "timeline"),
+		),
+		NextTab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next user"),
+			key.WithDisabled(),
+		),
+		PrevTab: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous user"),
+			key.WithDisabled(),
+		),
+		// Only enabled in split mode
+		SwitchPane: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch pane"),
+			key.WithDisabled(),
+		),
+		Palette: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command palette"),
+		),
+		Legend: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "icon legend"),
+		),
+		Badges: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "achievements"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v66/github"
+)
+
+// issueMeta is the labels, milestone and assignees of an issue or pull request event
+type issueMeta struct {
+	Labels    []*github.Label
+	Milestone *github.Milestone
+	Assignees []*github.User
+}
+
+// eventIssueMeta returns the issue metadata of IssuesEvent and PullRequestEvent payloads
+func eventIssueMeta(event *github.Event) (issueMeta, bool) {
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return issueMeta{}, false
+	}
+	switch p := payload.(type) {
+	case *github.IssuesEvent:
+		issue := p.GetIssue()
+		return issueMeta{issue.Labels, issue.Milestone, issue.Assignees}, true
+	case *github.PullRequestEvent:
+		pr := p.GetPullRequest()
+		return issueMeta{pr.Labels, pr.Milestone, pr.Assignees}, true
+	}
+	return issueMeta{}, false
+}
+
+// String is the plain text appended to descriptions (e.g. " [bug, ui] · v1.2 · @alice").
+// Table cells can't hold colors since they are truncated by width, so the badges
+// are only shown in the detail view.
+func (meta issueMeta) String() string {
+	var parts []string
+	if len(meta.Labels) > 0 {
+		var names []string
+		for _, label := range meta.Labels {
+			names = append(names, label.GetName())
+		}
+		parts = append(parts, "["+strings.Join(names, ", ")+"]")
+	}
+	if meta.Milestone != nil {
+		parts = append(parts, meta.Milestone.GetTitle())
+	}
+	if logins := meta.logins(); len(logins) > 0 {
+		parts = append(parts, strings.Join(logins, " "))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " " + strings.Join(parts, " · ")
+}
+
+func (meta issueMeta) logins() []string {
+	var logins []string
+	for _, user := range meta.Assignees {
+		logins = append(logins, "@"+user.GetLogin())
+	}
+	return logins
+}
+
+// markdown is the milestone and assignees section of the detail view
+func (meta issueMeta) markdown() string {
+	var sb strings.Builder
+	if meta.Milestone != nil {
+		fmt.Fprintf(&sb, "**Milestone:** %s  \n", meta.Milestone.GetTitle())
+	}
+	if logins := meta.logins(); len(logins) > 0 {
+		fmt.Fprintf(&sb, "**Assignees:** %s  \n", strings.Join(logins, ", "))
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// labelBadges renders the labels as badges in their GitHub colors
+func labelBadges(labels []*github.Label) string {
+	var badges []string
+	for _, label := range labels {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if color := label.GetColor(); len(color) == 6 {
+			style = style.Background(lipgloss.Color("#" + color)).Foreground(lipgloss.Color(labelForeground(color)))
+		} else {
+			style = style.Reverse(true)
+		}
+		badges = append(badges, style.Render(label.GetName()))
+	}
+	return strings.Join(badges, " ")
+}
+
+// labelForeground picks black or white text for a label's hex background, like GitHub does
+func labelForeground(hex string) string {
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "#ffffff"
+	}
+	r, g, b := float64(rgb>>16&0xff), float64(rgb>>8&0xff), float64(rgb&0xff)
+	if (0.299*r+0.587*g+0.114*b)/255 > 0.6 {
+		return "#000000"
+	}
+	return "#ffffff"
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// columnLayout bounds the width of an events table column, in terminal columns
+type columnLayout struct {
+	Min   int     `yaml:"min,omitempty"`
+	Max   int     `yaml:"max,omitempty"`
+	Ratio float64 `yaml:"ratio,omitempty"` // share of the terminal width rather than fitting the content
+}
+
+// tableLayout is the layout section of the config, keyed by lowercased column title
+// ("repository" also sizes the Repo column of --repo-column split)
+type tableLayout map[string]columnLayout
+
+// layoutColumns are the columns the layout can size (the Activity sparklines are fixed)
+var layoutColumns = []string{"date", "owner", "repository", "description"}
+
+// currentLayout is the active layout (see setLayout)
+var currentLayout tableLayout
+
+// setLayout checks the layout from the config and makes it the active one
+func setLayout(layout tableLayout) error {
+	var ratios float64
+	for name, col := range layout {
+		if !slices.Contains(layoutColumns, name) {
+			return fmt.Errorf("unknown layout column %s (must be one of: %s)", name, strings.Join(layoutColumns, ", "))
+		}
+		if col.Min < 0 || col.Max < 0 || (col.Max > 0 && col.Min > col.Max) {
+			return fmt.Errorf("invalid layout of column %s: min %d and max %d", name, col.Min, col.Max)
+		}
+		if col.Ratio < 0 || col.Ratio >= 1 {
+			return fmt.Errorf("invalid layout of column %s: ratio must be between 0 and 1", name)
+		}
+		ratios += col.Ratio
+	}
+	if ratios >= 1 {
+		return fmt.Errorf("invalid layout: the column ratios add up to %.2f (must be less than 1)", ratios)
+	}
+	currentLayout = layout
+	return nil
+}
+
+// fit sizes the column to its ratio of the terminal width (or else its content
+// width) within its min and max
+func (l tableLayout) fit(column string, content, termWidth int) int {
+	col := l[column]
+	width := content
+	if col.Ratio > 0 {
+		width = int(col.Ratio * float64(termWidth))
+	}
+	if col.Min > 0 {
+		width = max(width, col.Min)
+	}
+	if col.Max > 0 {
+		width = min(width, col.Max)
+	}
+	return width
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// legendEntry explains an icon shown in the events table
+type legendEntry struct {
+	icon, eventType, meaning string
+}
+
+// eventLegend lists the icons used by getEventDescription
+var eventLegend = []legendEntry{
+	{"", "PushEvent", "pushed commits"},
+	{prIcon, "PullRequestEvent", "pull request opened, synchronized, ..."},
+	{prDraftIcon, "PullRequestEvent", "draft pull request opened"},
+	{prMergedIcon, "PullRequestEvent", "pull request merged"},
+	{prClosedIcon, "PullRequestEvent", "pull request closed without merging"},
+	{prIcon + " " + reviewIcon, "PullRequestReviewEvent", "pull request review (or review thread)"},
+	{prIcon + " " + reviewApprovedIcon, "PullRequestReviewEvent", "pull request approved"},
+	{prIcon + " " + reviewChangesIcon, "PullRequestReviewEvent", "changes requested"},
+	{prIcon + " " + reviewCommentIcon, "PullRequestReviewEvent", "review comments"},
+	{"  ", "PullRequestReviewCommentEvent", "pull request review comment"},
+	{"󱋄", "IssuesEvent", "issue opened, closed, ..."},
+	{"󰅽", "IssueCommentEvent", "issue comment"},
+	{"󰆃", "CommitCommentEvent", "commit comment"},
+	{"󱓊", "CreateEvent", "created a branch"},
+	{"󱈢", "CreateEvent", "created a tag"},
+	{"󰳏", "CreateEvent", "created a repository"},
+	{"󰆴", "DeleteEvent", "deleted a branch or tag"},
+	{"󰎔", "ReleaseEvent", "published a release"},
+	{"", "ForkEvent", "forked a repository"},
+	{"⭐️", "WatchEvent", "starred a repository"},
+	{"󰷉", "GollumEvent", "wiki page created or edited"},
+	{"", "MemberEvent", "collaborator added"},
+	{"👀", "PublicEvent", "repository made public"},
+	{sponsorshipIcon, "SponsorshipEvent", "sponsorship created, changed or cancelled"},
+	{"❔", "", "event type without its own icon"},
+}
+
+// markerLegend lists the row markers
+var markerLegend = []legendEntry{
+	{strings.TrimSpace(unreadMarker), "", "new since your last visit"},
+	{strings.TrimSpace(bookmarkMarker), "", "bookmarked"},
+	{strings.TrimSpace(pinnedMarker), "", "pinned repository"},
+	{strings.TrimSpace(searchMarker), "", "matches the search"},
+}
+
+// legendView renders the full-screen icon key
+func (m model) legendView() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.Accent)
+	dim := lipgloss.NewStyle().Foreground(currentTheme.Dim)
+	iconWidth := 0
+	for _, e := range append(eventLegend, markerLegend...) {
+		iconWidth = max(iconWidth, lipgloss.Width(e.icon))
+	}
+	var sb strings.Builder
+	section := func(name string, entries []legendEntry) {
+		sb.WriteString(title.Render(name) + "\n\n")
+		for _, e := range entries {
+			icon := e.icon + strings.Repeat(" ", iconWidth-lipgloss.Width(e.icon))
+			line := colorIcons(e.icon) + icon[len(e.icon):] + "  " + e.meaning
+			if e.eventType != "" {
+				line += " " + dim.Render(e.eventType)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	section("Event icons", eventLegend)
+	sb.WriteString("\n")
+	section("Row markers", markerLegend)
+	return lipgloss.NewStyle().Padding(1, 2).Render(strings.TrimSuffix(sb.String(), "\n")) + "\n\n  press any key to close\n"
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v66/github"
+)
+
+// Provider is a source of events for a user (the GitHub API by default)
+type Provider interface {
+	Events(ctx context.Context, username string) ([]*github.Event, error)
+}
+
+// Options configure the embeddable fetcher and TUI (see pkg/gitfamous)
+type Options struct {
+	Users    []string
+	Token    string
+	Host     string // GitHub Enterprise Server host (default github.com)
+	Count    int
+	Filter   []string // event types or aliases like push, pr or code
+	Ignore   []string // actors whose events are hidden
+	Since    time.Duration
+	Provider Provider
+}
+
+// Event is a processed event as shown in the TUI
+type Event struct {
+	ID          string          `json:"id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Type        string          `json:"type"`
+	Actor       string          `json:"actor"`
+	Repo        string          `json:"repo"`
+	Description string          `json:"description"`
+	URL         string          `json:"url"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+}
+
+// fetchOptions converts the library options
+func (o Options) fetchOptions() (fetchOptions, error) {
+	filter, err := parseFilterTypes(o.Filter)
+	if err != nil {
+		return fetchOptions{}, err
+	}
+	return fetchOptions{
+		Count:       o.Count,
+		Since:       timeBound{rel: o.Since},
+		FilterTypes: filter,
+		IgnoreUsers: o.Ignore,
+		PerPage:     100,
+		Provider:    o.Provider,
+	}, nil
+}
+
+func (o Options) users() []User {
+	var users []User
+	for _, name := range o.Users {
+		users = append(users, User{Name: name, Token: o.Token, Host: o.Host})
+	}
+	return users
+}
+
+// providerEvents fetches events from a custom provider instead of the GitHub API
+func providerEvents(ctx context.Context, username string, opts fetchOptions) (*eventFeed, error) {
+	events, err := opts.Provider.Events(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	selected, _ := selectEvents(events, opts, nil)
+	items, err := toEventItems(username, selected, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &eventFeed{Items: items, FetchedAt: time.Now()}, nil
+}
+
+// Fetch returns the events of the first user in o without starting the TUI
+func Fetch(ctx context.Context, o Options) ([]Event, error) {
+	users := o.users()
+	if len(users) == 0 {
+		return nil, fmt.Errorf("a username is required")
+	}
+	opts, err := o.fetchOptions()
+	if err != nil {
+		return nil, err
+	}
+	// Don't touch the caller's gitfamous cache
+	opts.NoCache = true
+	client, err := newClient(users[0].Token, users[0].Host)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := fetchEvents(ctx, githubAPI{client}, users[0].Name, opts)
+	if err != nil {
+		return nil, err
+	}
+	webURL := "https://" + defaultHost
+	if o.Host != "" {
+		webURL = "https://" + o.Host
+	}
+	var events []Event
+	for _, item := range feed.Items {
+		e := Event{
+			CreatedAt:   item.CreatedAt,
+			Type:        item.Type,
+			Actor:       item.Actor.Login,
+			Repo:        item.Repository.Name,
+			Description: item.Description,
+			URL:         eventURL(item, webURL),
+		}
+		if item.Event != nil {
+			e.ID = item.Event.GetID()
+			e.Payload = item.Event.GetRawPayload()
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// NewModel returns the events TUI as a bubbletea model for embedding in another program
+func NewModel(o Options) (tea.Model, error) {
+	users := o.users()
+	if len(users) == 0 {
+		return nil, fmt.Errorf("a username is required")
+	}
+	opts, err := o.fetchOptions()
+	if err != nil {
+		return nil, err
+	}
+	view := viewOptions{TimeFormat: timeFormatRelative, EnterAction: enterActionBrowser}
+	var tabs []model
+	for _, user := range users {
+		tabs = append(tabs, initialModel(user, opts, view))
+	}
+	if len(tabs) == 1 {
+		return tabs[0], nil
+	}
+	return initialMultiUserModel(tabs, opts, view), nil
+}
+
+// Run runs the events TUI until it's quit or ctx is cancelled
+func Run(ctx context.Context, o Options) error {
+	m, err := NewModel(o)
+	if err != nil {
+		return err
+	}
+	detectBackground()
+	_, err = tea.NewProgram(m, tea.WithContext(ctx), tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+	return err
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// testServerAPI returns the eventsAPI of a go-github client talking to a server
+// with octocat's profile and a push event
+func testServerAPI(t *testing.T) eventsAPI {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/octocat", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"login":"octocat"}`))
+	})
+	mux.HandleFunc("/users/octocat/events/public", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"1","type":"WatchEvent","actor":{"login":"octocat"},"repo":{"name":"blacktop/ipsw"},
+			"payload":{"action":"started"},"created_at":"2024-11-05T14:30:00Z"}]`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return githubAPI{client}
+}
+
+func TestNoCacheFetchLeavesCacheAlone(t *testing.T) {
+	setupTestHome(t)
+	api := testServerAPI(t)
+	feed, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{NoCache: true})
+	if err != nil {
+		t.Fatalf("fetchEvents() error = %v", err)
+	}
+	if len(feed.Items) != 1 {
+		t.Fatalf("fetched %d events, want 1", len(feed.Items))
+	}
+	dir, err := cacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+		t.Errorf("library fetch wrote %s to the cache", entries[0].Name())
+	}
+}
+
+func TestMetricsNotRegisteredOnImport(t *testing.T) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if strings.HasPrefix(f.GetName(), "gitfamous_") {
+			t.Errorf("%s is registered without running watch", f.GetName())
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// langFlag is the --lang used for relative dates (default from $LANG)
+var langFlag string
+
+// locale is the wording of relative dates in a language where the
+// "ago" label comes first (e.g. "hace 3 días", "vor 3 Tagen")
+type locale struct {
+	ago, fromNow string
+	now          string
+	longAgo      string // e.g. "mucho tiempo" for "hace mucho tiempo"
+	// units are the singular and plural of second, minute, hour, day, week, month and year
+	units [7][2]string
+}
+
+var locales = map[string]locale{
+	"de": {
+		ago: "vor", fromNow: "in", now: "jetzt", longAgo: "langer Zeit",
+		units: [7][2]string{{"Sekunde", "Sekunden"}, {"Minute", "Minuten"}, {"Stunde", "Stunden"}, {"Tag", "Tagen"}, {"Woche", "Wochen"}, {"Monat", "Monaten"}, {"Jahr", "Jahren"}},
+	},
+	"es": {
+		ago: "hace", fromNow: "dentro de", now: "ahora", longAgo: "mucho tiempo",
+		units: [7][2]string{{"segundo", "segundos"}, {"minuto", "minutos"}, {"hora", "horas"}, {"día", "días"}, {"semana", "semanas"}, {"mes", "meses"}, {"año", "años"}},
+	},
+	"fr": {
+		ago: "il y a", fromNow: "dans", now: "maintenant", longAgo: "longtemps",
+		units: [7][2]string{{"seconde", "secondes"}, {"minute", "minutes"}, {"heure", "heures"}, {"jour", "jours"}, {"semaine", "semaines"}, {"mois", "mois"}, {"an", "ans"}},
+	},
+	"pt": {
+		ago: "há", fromNow: "em", now: "agora", longAgo: "muito tempo",
+		units: [7][2]string{{"segundo", "segundos"}, {"minuto", "minutos"}, {"hora", "horas"}, {"dia", "dias"}, {"semana", "semanas"}, {"mês", "meses"}, {"ano", "anos"}},
+	},
+}
+
+// magnitudes mirrors humanize's default magnitudes in the locale's words
+func (l locale) magnitudes() []humanize.RelTimeMagnitude {
+	const (
+		day   = 24 * time.Hour
+		week  = 7 * day
+		month = 30 * day
+		year  = 12 * month
+	)
+	one := func(unit int) string { return "%s 1 " + l.units[unit][0] }
+	many := func(unit int) string { return "%s %d " + l.units[unit][1] }
+	return []humanize.RelTimeMagnitude{
+		{D: time.Second, Format: l.now, DivBy: time.Second},
+		{D: 2 * time.Second, Format: one(0), DivBy: 1},
+		{D: time.Minute, Format: many(0), DivBy: time.Second},
+		{D: 2 * time.Minute, Format: one(1), DivBy: 1},
+		{D: time.Hour, Format: many(1), DivBy: time.Minute},
+		{D: 2 * time.Hour, Format: one(2), DivBy: 1},
+		{D: day, Format: many(2), DivBy: time.Hour},
+		{D: 2 * day, Format: one(3), DivBy: 1},
+		{D: week, Format: many(3), DivBy: day},
+		{D: 2 * week, Format: one(4), DivBy: 1},
+		{D: month, Format: many(4), DivBy: week},
+		{D: 2 * month, Format: one(5), DivBy: 1},
+		{D: year, Format: many(5), DivBy: month},
+		{D: 18 * month, Format: one(6), DivBy: 1},
+		{D: 2 * year, Format: "%s 2 " + l.units[6][1], DivBy: 1},
+		{D: 37 * year, Format: many(6), DivBy: year},
+		{D: math.MaxInt64, Format: "%s " + l.longAgo, DivBy: 1},
+	}
+}
+
+// currentLocale is the locale of relative dates, nil for English
+var currentLocale *locale
+
+// setLocale selects the language of relative dates from --lang or else the
+// environment ($LC_ALL, $LC_MESSAGES, $LANG), falling back to English
+func setLocale(lang string) error {
+	explicit := lang != ""
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang != "" {
+			break
+		}
+		lang = os.Getenv(env)
+	}
+	// e.g. es_ES.UTF-8 or pt-BR
+	name, _, _ := strings.Cut(strings.ToLower(lang), ".")
+	name, _, _ = strings.Cut(name, "_")
+	name, _, _ = strings.Cut(name, "-")
+	if l, ok := locales[name]; ok {
+		currentLocale = &l
+		return nil
+	}
+	currentLocale = nil
+	if explicit && name != "en" {
+		return fmt.Errorf("unsupported --lang %s (must be one of: %s)", lang, strings.Join(langNames(), ", "))
+	}
+	return nil
+}
+
+// langNames returns the supported --lang values
+func langNames() []string {
+	names := []string{"en"}
+	for name := range locales {
+		names = append(names, name)
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+// humanTime formats t relative to now (e.g. "3 days ago") in the current locale
+func humanTime(t time.Time) string {
+	if currentLocale == nil {
+		return humanize.Time(t)
+	}
+	return humanize.CustomRelTime(t, time.Now(), currentLocale.ago, currentLocale.fromNow, currentLocale.magnitudes())
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// logFile is where debug logs go (--log-file); bubbletea owns the terminal so they can't go to stderr
+var logFile string
+
+// errorTee writes every log line to the log file and also echoes errors to stderr
+type errorTee struct {
+	file   io.Writer
+	stderr io.Writer
+}
+
+func (w errorTee) Write(p []byte) (int, error) {
+	// Lines look like: time=... level=error msg=...
+	if _, rest, ok := bytes.Cut(p, []byte(" ")); ok && bytes.HasPrefix(rest, []byte("level=error ")) {
+		w.stderr.Write(p)
+	}
+	return w.file.Write(p)
+}
+
+// setupLogging sends debug logs to --log-file (if set) and returns a func to close it
+func setupLogging() (func() error, error) {
+	if logFile == "" {
+		return func() error { return nil }, nil
+	}
+	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	logger.SetOutput(errorTee{file: f, stderr: os.Stderr})
+	logger.SetFormatter(log.LogfmtFormatter)
+	logger.SetReportTimestamp(true)
+	logger.SetTimeFormat(time.RFC3339Nano)
+	logger.SetLevel(log.DebugLevel)
+	logger.Debug("starting", "version", version(), "commit", buildCommit())
+	return f.Close, nil
+}
+
+// debugEnabled reports whether debug logs are written anywhere
+func debugEnabled() bool {
+	return logger.GetLevel() <= log.DebugLevel
+}
+
+// logTransport logs every API request with its status and rate limit
+type logTransport struct {
+	base http.RoundTripper
+}
+
+func (t logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		logger.Debug("api request failed", "method", req.Method, "url", req.URL.String(), "duration", time.Since(start), "error", err)
+		return resp, err
+	}
+	logger.Debug("api request", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode,
+		"duration", time.Since(start), "rate_remaining", resp.Header.Get("X-RateLimit-Remaining"))
+	return resp, nil
+}
+
+// traceHTTP also logs the headers of API requests and responses (--trace-http)
+var traceHTTP bool
+
+// traceTransport logs the headers of every API request and response, without the token
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+func (t traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !debugEnabled() {
+		return t.base.RoundTrip(req)
+	}
+	out := req.Clone(req.Context())
+	if out.Header.Get("Authorization") != "" {
+		out.Header.Set("Authorization", "[redacted]")
+	}
+	if dump, err := httputil.DumpRequestOut(out, false); err == nil {
+		logger.Debug("api request headers", "dump", httpDump(dump))
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		if dump, err := httputil.DumpResponse(resp, false); err == nil {
+			logger.Debug("api response headers", "dump", httpDump(dump))
+		}
+	}
+	return resp, err
+}
+
+// httpDump tidies up a httputil dump for the logs
+func httpDump(dump []byte) string {
+	return strings.TrimSpace(strings.ReplaceAll(string(dump), "\r\n", "\n"))
+}
+
+// traceTiming logs how long an operation took, use as: defer traceTiming("fetch", time.Now(), "user", name)
+func traceTiming(op string, start time.Time, keyvals ...any) {
+	if debugEnabled() {
+		logger.Debug("timing", append([]any{"op", op, "duration", time.Since(start)}, keyvals...)...)
+	}
+}
+
+// traceRender logs how long rendering a view took, use as: defer traceRender("events", time.Now())
+func traceRender(view string, start time.Time) {
+	if debugEnabled() {
+		logger.Debug("render", "view", view, "duration", time.Since(start))
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v66/github"
+)
+
+// meMode shows the token's own user with their private events, the events they
+// received and their notification count (--me)
+var meMode bool
+
+// meUser returns the user the token of the account belongs to (--me, fans)
+func meUser(conf *Config) (User, error) {
+	acct, err := conf.account(accountName)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to load account: %v", err)
+	}
+	token := cmp.Or(acct.Token, githubToken)
+	if token == "" {
+		return User{}, fmt.Errorf("a Github API token is required to know who you are")
+	}
+	login, err := viewerLogin(context.Background(), token, acct.Host)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get the user of the token: %v", err)
+	}
+	return User{Name: login, Token: token, Host: acct.Host, Account: accountName}, nil
+}
+
+// title is the name of the tab, "received" for the events a user received
+func (m model) title() string {
+	if m.fetch.Received {
+		return "received"
+	}
+	return m.username
+}
+
+// seenKey is what the newest seen event of the tab is recorded under (see markUnread)
+func (m model) seenKey() string {
+	if m.fetch.Received {
+		return m.username + "/received"
+	}
+	return m.username
+}
+
+// notificationsPerPage is how many unread notifications are counted before "50+"
+const notificationsPerPage = 50
+
+// notificationsMsg is the user's unread notification count, e.g. "3" or "50+"
+type notificationsMsg struct {
+	username string
+	count    string
+	err      error
+}
+
+// notificationsCmd counts the unread notifications of the token's user, or nil
+// when they aren't shown
+func (m model) notificationsCmd() tea.Cmd {
+	if !m.showNotifications {
+		return nil
+	}
+	username, token, host := m.username, m.apiToken, m.host
+	return func() tea.Msg {
+		client, err := newClient(token, host)
+		if err != nil {
+			return notificationsMsg{username: username, err: err}
+		}
+		notes, resp, err := client.Activity.ListNotifications(context.Background(), &github.NotificationListOptions{
+			ListOptions: github.ListOptions{PerPage: notificationsPerPage},
+		})
+		if err != nil {
+			return notificationsMsg{username: username, err: err}
+		}
+		count := fmt.Sprint(len(notes))
+		if resp.NextPage != 0 {
+			count += "+"
+		}
+		return notificationsMsg{username: username, count: count}
+	}
+}
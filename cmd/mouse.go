@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// tableRowsOffset is the number of lines above the first table row (top border, header and header border)
+	tableRowsOffset   = 3
+	doubleClickWindow = 400 * time.Millisecond
+	// cursorMarker is injected into the selected row to locate it in the rendered table
+	cursorMarker = "\uE000"
+)
+
+// tableTopRow returns the index of the first row currently visible in the table viewport.
+// The table model doesn't expose its scroll offset, so we render it with a marked
+// selected row and work back from the cursor position.
+func (m model) tableTopRow() int {
+	probe := m.table
+	styles := m.tableStyles
+	styles.Selected = lipgloss.NewStyle().Transform(func(s string) string {
+		return cursorMarker + s
+	})
+	probe.SetStyles(styles)
+	lines := strings.Split(probe.View(), "\n")
+	headerHeight := tableRowsOffset - 1 // no outer border when rendering the bare table
+	for i, line := range lines[min(headerHeight, len(lines)):] {
+		if strings.Contains(line, cursorMarker) {
+			return m.table.Cursor() - i
+		}
+	}
+	return 0
+}
+
+func (m model) handleMouse(msg tea.MouseMsg) (model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.table.MoveUp(1)
+	case tea.MouseButtonWheelDown:
+		m.table.MoveDown(1)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			break
+		}
+		line := msg.Y - tableRowsOffset - m.bannerHeight()
+		if line == -2 {
+			// The header row
+			m.sortByColumn(msg.X)
+			break
+		}
+		if line < 0 || line >= m.table.Height() {
+			break
+		}
+		row := m.tableTopRow() + line
+		if row < 0 || row >= len(m.table.Rows()) {
+			break
+		}
+		if row > m.table.Cursor() {
+			m.table.MoveDown(row - m.table.Cursor())
+		} else if row < m.table.Cursor() {
+			m.table.MoveUp(m.table.Cursor() - row)
+		}
+		now := time.Now()
+		if row == m.lastClickRow && now.Sub(m.lastClick) < doubleClickWindow {
+			m.handleEnterKey()
+			m.lastClick = time.Time{}
+			break
+		}
+		m.lastClick = now
+		m.lastClickRow = row
+	}
+	return m, nil
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+const tabBarHeight = 1
+
+var (
+	activeTabStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	inactiveTabStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	tabIndicatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("63")).Bold(true)
+)
+
+// multiUserModel shows one tab per tracked user
+type multiUserModel struct {
+	tabs   []model
+	active int
+	width  int
+	height int
+
+	// fetch and opts are used for tabs added when the config is reloaded
+	fetch fetchOptions
+	opts  viewOptions
+}
+
+func initialMultiUserModel(tabs []model, fetch fetchOptions, opts viewOptions) multiUserModel {
+	for i := range tabs {
+		tabs[i].keys.NextTab.SetEnabled(true)
+		tabs[i].keys.PrevTab.SetEnabled(true)
+	}
+	return multiUserModel{tabs: tabs, fetch: fetch, opts: opts}
+}
+
+func (m multiUserModel) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, tab := range m.tabs {
+		cmds = append(cmds, tab.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m multiUserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		var cmds []tea.Cmd
+		for i := range m.tabs {
+			tab, cmd := m.tabs[i].Update(tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height - tabBarHeight})
+			m.tabs[i] = tab.(model)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+
+	// Results go to the tab that asked for them, which needn't be the active one
+	case fetchEventsMsg:
+		return m.updateTab(msg.username, msg.received, msg)
+	case fetchRepoMsg:
+		return m.updateTab(msg.username, msg.received, msg)
+	case fetchCommitsMsg:
+		return m.updateTab(msg.username, msg.received, msg)
+	case confirmMsg:
+		return m.updateTab(msg.username, msg.received, msg)
+	case actionDoneMsg:
+		return m.updateTab(msg.username, msg.received, msg)
+
+	case notificationsMsg:
+		for i := range m.tabs {
+			if m.tabs[i].username == msg.username && m.tabs[i].showNotifications {
+				updated, cmd := m.tabs[i].Update(msg)
+				m.tabs[i] = updated.(model)
+				return m, cmd
+			}
+		}
+		return m, nil
+
+	case loadMoreMsg:
+		return m.updateTab(msg.username, msg.received, msg)
+
+	case configReloadMsg:
+		return m.reloadConfig(msg)
+
+	case spinner.TickMsg:
+		// Keep the spinners of background tabs going
+		var cmds []tea.Cmd
+		for i := range m.tabs {
+			updated, cmd := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(model)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.MouseMsg:
+		if msg.Y < tabBarHeight {
+			if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+				m.clickTab(msg.X)
+			}
+			return m, nil
+		}
+		msg.Y -= tabBarHeight
+		tab, cmd := m.tabs[m.active].Update(msg)
+		m.tabs[m.active] = tab.(model)
+		return m, cmd
+
+	case tea.KeyMsg:
+		current := m.tabs[m.active]
+		if !current.capturingInput() {
+			switch {
+			case key.Matches(msg, current.keys.NextTab):
+				m.active = (m.active + 1) % len(m.tabs)
+				return m, nil
+			case key.Matches(msg, current.keys.PrevTab):
+				m.active = (m.active - 1 + len(m.tabs)) % len(m.tabs)
+				return m, nil
+			case key.Matches(msg, current.keys.Screenshot) && current.err == nil:
+				// Include the tab bar in the screenshot
+				base, err := saveScreenshot(m.View(), current.username)
+				m.tabs[m.active].status = screenshotStatus(base, err)
+				return m, nil
+			}
+		}
+	}
+
+	tab, cmd := m.tabs[m.active].Update(msg)
+	m.tabs[m.active] = tab.(model)
+	return m, cmd
+}
+
+// updateTab passes msg to the tab of username's (received) events, dropping it when
+// the tab has been closed since
+func (m multiUserModel) updateTab(username string, received bool, msg tea.Msg) (tea.Model, tea.Cmd) {
+	for i := range m.tabs {
+		if m.tabs[i].username != username || m.tabs[i].fetch.Received != received {
+			continue
+		}
+		tab, cmd := m.tabs[i].Update(msg)
+		m.tabs[i] = tab.(model)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m multiUserModel) View() string {
+	return m.tabBar() + "\n" + m.tabs[m.active].View()
+}
+
+// tabLayout works out which window of tabs fits in the terminal width
+// around the active tab, and whether tabs are hidden to either side.
+func (m multiUserModel) tabLayout() (first, last int, labels []string) {
+	width := m.width
+	if width == 0 {
+		var err error
+		width, _, err = term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			width = 80
+		}
+	}
+
+	labels = make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		title := tab.title()
+		label := " " + title + " "
+		if n := tab.unreadCount(); n > 0 {
+			label = fmt.Sprintf(" %s (%d) ", title, n)
+		}
+		if tab.err != nil {
+			label = " " + title + " ! "
+		}
+		if n := queued(tab.username); n > 0 {
+			// Waiting on the rate limiter shared by all tabs
+			label = fmt.Sprintf(" %s %s%d ", title, queuedMarker, n)
+		}
+		labels[i] = label
+	}
+
+	// Reserve room for the overflow indicators and the counter
+	avail := width - lipgloss.Width(m.tabCounter()) - 4
+	first, last = m.active, m.active
+	used := lipgloss.Width(labels[m.active])
+	for {
+		grew := false
+		if last+1 < len(labels) && used+lipgloss.Width(labels[last+1])+1 <= avail {
+			last++
+			used += lipgloss.Width(labels[last]) + 1
+			grew = true
+		}
+		if first > 0 && used+lipgloss.Width(labels[first-1])+1 <= avail {
+			first--
+			used += lipgloss.Width(labels[first]) + 1
+			grew = true
+		}
+		if !grew {
+			break
+		}
+	}
+	return first, last, labels
+}
+
+func (m multiUserModel) tabCounter() string {
+	return fmt.Sprintf(" [%d/%d]", m.active+1, len(m.tabs))
+}
+
+func (m multiUserModel) tabBar() string {
+	first, last, labels := m.tabLayout()
+
+	var sb strings.Builder
+	if first > 0 {
+		sb.WriteString(tabIndicatorStyle.Render("‹ "))
+	} else {
+		sb.WriteString("  ")
+	}
+	for i := first; i <= last; i++ {
+		if i > first {
+			sb.WriteString(" ")
+		}
+		if i == m.active {
+			sb.WriteString(activeTabStyle.Render(labels[i]))
+		} else {
+			sb.WriteString(inactiveTabStyle.Render(labels[i]))
+		}
+	}
+	if last < len(labels)-1 {
+		sb.WriteString(tabIndicatorStyle.Render(" ›"))
+	}
+	if first > 0 || last < len(labels)-1 {
+		sb.WriteString(inactiveTabStyle.Render(m.tabCounter()))
+	}
+	return sb.String()
+}
+
+// clickTab selects the tab (or scrolls the tab bar) at column x of the tab bar
+func (m *multiUserModel) clickTab(x int) {
+	first, last, labels := m.tabLayout()
+	if x < 2 {
+		if first > 0 {
+			m.active = first - 1
+		}
+		return
+	}
+	pos := 2
+	for i := first; i <= last; i++ {
+		w := lipgloss.Width(labels[i])
+		if x >= pos && x < pos+w {
+			m.active = i
+			return
+		}
+		pos += w + 1
+	}
+	if last < len(labels)-1 && x < pos+2 {
+		m.active = last + 1
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// mutedRepo reports whether repo is one of the muted repositories
+func mutedRepo(muted []string, repo string) bool {
+	return slices.ContainsFunc(muted, func(r string) bool { return strings.EqualFold(r, repo) })
+}
+
+// saveMutedRepos updates muted_repos in the config file with the repos muted or
+// unmuted going from before to after
+func saveMutedRepos(before, after []string) error {
+	conf, err := readConfig(configPath)
+	if err != nil {
+		return err
+	}
+	list := slices.DeleteFunc(conf.MutedRepos, func(repo string) bool {
+		return mutedRepo(before, repo) && !mutedRepo(after, repo)
+	})
+	for _, repo := range after {
+		if !mutedRepo(list, repo) {
+			list = append(list, repo)
+		}
+	}
+	return setConfigList(configPath, "muted_repos", list)
+}
+
+// muteRepo hides the events of the selected event's repository, now and in future
+// fetches, and adds it to muted_repos in the config file
+func (m *model) muteRepo() {
+	item, ok := m.selectedEvent()
+	if !ok || item.Repository.Name == "" {
+		return
+	}
+	name := item.Repository.Name
+	m.pushView()
+	muted := append(slices.Clip(m.fetch.MutedRepos), name)
+	m.status = fmt.Sprintf("muted %s (ctrl+z to undo)", name)
+	if err := saveMutedRepos(m.fetch.MutedRepos, muted); err != nil {
+		m.status = fmt.Sprintf("muted %s for now, failed to save it: %v", name, err)
+	}
+	m.fetch.MutedRepos = muted
+	cursor := m.table.Cursor()
+	m.setupTable()
+	m.table.SetCursor(min(cursor, max(len(m.visible)-1, 0)))
+}
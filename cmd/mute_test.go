@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMuteLastRepo(t *testing.T) {
+	setupTestHome(t)
+	m := loadedModel(t, testEvents()[1:2], 100, 30)
+	m.muteRepo()
+	if got := len(m.visible); got != 0 {
+		t.Fatalf("visible = %d after muting the only repo, want 0", got)
+	}
+	if _, ok := m.selectedEvent(); ok {
+		t.Error("selected an event with none visible")
+	}
+	if view := m.View(); !strings.Contains(view, "no events to show (ctrl+z to undo)") {
+		t.Errorf("view without events doesn't say so:\n%s", view)
+	}
+
+	m.undoView()
+	if got := len(m.visible); got != 1 {
+		t.Errorf("visible = %d after undoing the mute, want 1", got)
+	}
+}
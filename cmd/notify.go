@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// notification is a new event to announce
+type notification struct {
+	User string
+	Item eventItem
+	URL  string // link to the event on GitHub
+}
+
+// text renders the notification as a single message
+func (n notification) text() string {
+	text := fmt.Sprintf("%s %s in %s: %s", n.User, strings.TrimSuffix(n.Item.Type, "Event"), n.Item.Repository.Name, strings.TrimSpace(n.Item.Description))
+	if n.URL != "" {
+		text += "\n" + n.URL
+	}
+	return text
+}
+
+// sink delivers notifications somewhere (a chat channel, ...)
+type sink interface {
+	Send(ctx context.Context, n notification) error
+}
+
+// webhookSink posts notifications to a chat incoming webhook
+type webhookSink struct {
+	name string
+	url  string
+	body func(text string) any // builds the JSON payload for the service
+}
+
+// webhookTimeout bounds each webhook post so a hanging service can't stall the watch loop
+var webhookTimeout = 10 * time.Second
+
+func (s webhookSink) Send(ctx context.Context, n notification) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	data, err := json.Marshal(s.body(n.text()))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to notify %s: %v", s.name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to notify %s: %s", s.name, resp.Status)
+	}
+	return nil
+}
+
+// filteredSink only forwards the notifications for some users and event types
+type filteredSink struct {
+	sink
+	types []string
+	users []string
+}
+
+func (s filteredSink) wants(n notification) bool {
+	if len(s.types) > 0 && !slices.Contains(s.types, n.Item.Type) {
+		return false
+	}
+	return len(s.users) == 0 || slices.ContainsFunc(s.users, func(u string) bool { return strings.EqualFold(u, n.User) })
+}
+
+// newSink creates the sink configured by conf
+func newSink(conf Notify) (filteredSink, error) {
+	url := os.ExpandEnv(conf.URL)
+	if url == "" {
+		return filteredSink{}, fmt.Errorf("notify %s: url is required", conf.Type)
+	}
+	var s sink
+	switch strings.ToLower(conf.Type) {
+	case "slack":
+		s = webhookSink{name: "slack", url: url, body: func(text string) any {
+			return map[string]string{"text": text}
+		}}
+	case "discord":
+		s = webhookSink{name: "discord", url: url, body: func(text string) any {
+			return map[string]string{"content": text}
+		}}
+	default:
+		return filteredSink{}, fmt.Errorf("unsupported notify type %s (must be slack or discord)", conf.Type)
+	}
+	types, err := parseFilterTypes(conf.Filter)
+	if err != nil {
+		return filteredSink{}, fmt.Errorf("notify %s: %v", conf.Type, err)
+	}
+	return filteredSink{sink: s, types: types, users: conf.Users}, nil
+}
+
+// loadSinks creates the sinks from the config's notify section
+func loadSinks(conf *Config) ([]filteredSink, error) {
+	var sinks []filteredSink
+	for _, n := range conf.Notify {
+		s, err := newSink(n)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// notify sends n to every sink that wants it
+func notify(ctx context.Context, sinks []filteredSink, n notification) {
+	for _, s := range sinks {
+		if !s.wants(n) {
+			continue
+		}
+		if err := s.Send(ctx, n); err != nil {
+			logger.Warn("sending notification", "error", err)
+		}
+	}
+}
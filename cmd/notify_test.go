@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookSinkTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	old := webhookTimeout
+	webhookTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { webhookTimeout = old })
+
+	s := webhookSink{name: "slack", url: srv.URL, body: func(text string) any { return map[string]string{"text": text} }}
+	n := notification{User: "octocat", Item: testEvents()[0]}
+	start := time.Now()
+	if err := s.Send(context.Background(), n); err == nil {
+		t.Error("Send() to a hanging webhook succeeded, want a timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send() to a hanging webhook took %v", elapsed)
+	}
+}
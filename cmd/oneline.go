@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	onelineSince  string
+	onelineMaxAge time.Duration
+	onelineColor  string
+)
+
+// onelineLabel is how an event type is counted in the one-line summary
+type onelineLabel struct {
+	singular, plural string
+	color            string // xterm 256-color index
+}
+
+var onelineLabels = map[string]onelineLabel{
+	"PushEvent":                     {"push", "pushes", "33"},
+	"PullRequestEvent":              {"PR", "PRs", "135"},
+	"PullRequestReviewEvent":        {"review", "reviews", "99"},
+	"PullRequestReviewCommentEvent": {"comment", "comments", "245"},
+	"PullRequestReviewThreadEvent":  {"review", "reviews", "99"},
+	"IssuesEvent":                   {"issue", "issues", "71"},
+	"IssueCommentEvent":             {"comment", "comments", "245"},
+	"CommitCommentEvent":            {"comment", "comments", "245"},
+	"ReleaseEvent":                  {"release", "releases", "208"},
+	"WatchEvent":                    {"star", "stars", "220"},
+	"ForkEvent":                     {"fork", "forks", "37"},
+	"CreateEvent":                   {"create", "creates", "114"},
+	"DeleteEvent":                   {"delete", "deletes", "167"},
+	"GollumEvent":                   {"wiki edit", "wiki edits", "180"},
+	"MemberEvent":                   {"new member", "new members", "140"},
+	"PublicEvent":                   {"repo made public", "repos made public", "114"},
+	"SponsorshipEvent":              {"sponsorship", "sponsorships", "205"},
+}
+
+// colorize wraps s in the escape codes for --color
+func colorize(s, color, mode string) string {
+	switch mode {
+	case "ansi":
+		return "\x1b[38;5;" + color + "m" + s + "\x1b[0m"
+	case "tmux":
+		return "#[fg=colour" + color + "]" + s + "#[default]"
+	}
+	return s
+}
+
+// onelineSummary counts the events by type, e.g. "3 PRs, 12 pushes, 1 release in last 24h"
+func onelineSummary(items []eventItem, window, mode string) string {
+	counts := make(map[onelineLabel]int)
+	for _, item := range items {
+		label, ok := onelineLabels[item.Type]
+		if !ok {
+			name := strings.ToLower(strings.TrimSuffix(item.Type, "Event"))
+			label = onelineLabel{name, name + "s", "245"}
+		}
+		counts[label]++
+	}
+	if len(counts) == 0 {
+		return "no activity in last " + window
+	}
+	labels := slices.Collect(maps.Keys(counts))
+	slices.SortFunc(labels, func(a, b onelineLabel) int {
+		return cmp.Or(counts[b]-counts[a], strings.Compare(a.singular, b.singular))
+	})
+	var parts []string
+	for _, label := range labels {
+		name := label.plural
+		if counts[label] == 1 {
+			name = label.singular
+		}
+		parts = append(parts, colorize(fmt.Sprintf("%d %s", counts[label], name), label.color, mode))
+	}
+	return strings.Join(parts, ", ") + " in last " + window
+}
+
+var onelineCmd = &cobra.Command{
+	Use:   "oneline <username>",
+	Short: "Print a one-line activity summary for tmux status bars and shell prompts",
+	Long: `Print a compact summary of a user's recent activity like
+"3 PRs, 12 pushes, 1 release in last 24h" for embedding in a tmux status bar or
+a shell prompt.
+
+Events cached within --max-age are used without touching the API (or the config),
+so the status bar can refresh every few seconds:
+
+  set -g status-right '#(gitfamous oneline blacktop --color tmux)'`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch onelineColor {
+		case "none", "ansi", "tmux":
+		default:
+			return fmt.Errorf("invalid --color %s (must be none, ansi or tmux)", onelineColor)
+		}
+		sinceBound, err := parseTimeBound(onelineSince)
+		if err != nil {
+			return err
+		}
+		filter, err := parseFilterTypes(filterTypes)
+		if err != nil {
+			return err
+		}
+		opts := fetchOptions{Since: sinceBound, FilterTypes: filter, PerPage: 100}
+
+		// Skip the config (token commands can be slow) and the API while the cache is fresh
+		// (the host that keys the cache only needs the config file itself)
+		username := args[0]
+		if conf, err := readConfig(configPath); err == nil {
+			if acct, err := conf.account(accountName); err == nil {
+				opts.Host = acct.Host
+			}
+		}
+		var feed *eventFeed
+		if cache, err := loadCache(username, opts.Host); err == nil && time.Since(cache.FetchedAt) < onelineMaxAge {
+			feed, err = fetchCachedEvents(username, opts)
+			if err != nil && !strings.HasPrefix(err.Error(), "no events found") {
+				return err
+			}
+		} else {
+			_, users, err := setupSubcommand(args)
+			if err != nil {
+				return err
+			}
+			client, err := newClient(users[0].Token, users[0].Host)
+			if err != nil {
+				return err
+			}
+			feed, err = fetchEvents(context.Background(), githubAPI{client}, users[0].Name, opts)
+			if err != nil && !strings.HasPrefix(err.Error(), "no events found") {
+				return err
+			}
+		}
+		var items []eventItem
+		if feed != nil {
+			items = feed.Items
+		}
+		fmt.Println(onelineSummary(items, onelineSince, onelineColor))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(onelineCmd)
+	onelineCmd.Flags().StringVarP(&onelineSince, "since", "s", "24h", "Time window to summarize (e.g. 24h, 1w)")
+	onelineCmd.Flags().DurationVar(&onelineMaxAge, "max-age", 5*time.Minute, "Use cached events fetched within this long instead of calling the API")
+	onelineCmd.Flags().StringVar(&onelineColor, "color", "none", "Color the counts: none, ansi (shell prompts) or tmux (status bars)")
+	onelineCmd.Flags().StringSliceVarP(&filterTypes, "filter", "f", nil, "Comma-separated list of event types or aliases to count")
+	onelineCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	onelineCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	onelineCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	onelineCmd.RegisterFlagCompletionFunc("filter", completeFilter)
+	onelineCmd.RegisterFlagCompletionFunc("account", completeAccount)
+	onelineCmd.RegisterFlagCompletionFunc("color", cobra.FixedCompletions([]string{"none", "ansi", "tmux"}, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v66/github"
+	"golang.org/x/term"
+)
+
+var pagerFooterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+// eventBody returns the markdown body of comment, issue, PR and release events
+func eventBody(event *github.Event) (string, bool) {
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return "", false
+	}
+	switch p := payload.(type) {
+	case *github.CommitCommentEvent:
+		return p.GetComment().GetBody(), true
+	case *github.IssueCommentEvent:
+		return p.GetComment().GetBody(), true
+	case *github.IssuesEvent:
+		return p.GetIssue().GetBody(), true
+	case *github.PullRequestEvent:
+		return p.GetPullRequest().GetBody(), true
+	case *github.PullRequestReviewEvent:
+		return p.GetReview().GetBody(), true
+	case *github.PullRequestReviewCommentEvent:
+		return p.GetComment().GetBody(), true
+	case *github.ReleaseEvent:
+		rel, _ := eventRelease(event)
+		return rel.GetBody(), true
+	}
+	return "", false
+}
+
+// pagerContent builds the markdown document shown for an event in the pager
+func pagerContent(item eventItem, date string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", item.Type)
+	fmt.Fprintf(&sb, "**Repository:** %s  \n", item.Repository.Name)
+	fmt.Fprintf(&sb, "**Date:** %s\n\n", date)
+	fmt.Fprintf(&sb, "%s\n\n", item.Description)
+	if item.Stats != nil {
+		fmt.Fprintf(&sb, "**Changes:** %s in %d files\n\n", item.Stats, item.Stats.Files)
+	}
+	if item.CI != "" {
+		fmt.Fprintf(&sb, "**CI:** %s %s\n\n", item.CI.icon(), item.CI)
+	}
+	if item.Event == nil {
+		return sb.String()
+	}
+	if meta, ok := eventIssueMeta(item.Event); ok {
+		sb.WriteString(meta.markdown())
+	}
+	if rel, ok := eventRelease(item.Event); ok {
+		sb.WriteString(releaseMarkdown(rel))
+	}
+	if body, ok := eventBody(item.Event); ok {
+		if strings.TrimSpace(body) == "" {
+			body = "_No description provided._"
+		}
+		fmt.Fprintf(&sb, "---\n\n%s\n", body)
+		return sb.String()
+	}
+	// Fall back to the raw payload for everything else
+	var out bytes.Buffer
+	if err := json.Indent(&out, item.Event.GetRawPayload(), "", "  "); err == nil {
+		fmt.Fprintf(&sb, "```json\n%s\n```\n", out.String())
+	}
+	return sb.String()
+}
+
+func (m *model) openPager() tea.Cmd {
+	item, ok := m.selectedEvent()
+	if !ok {
+		return nil
+	}
+	m.pagerItem = item
+	m.renderPager()
+	m.showPager = true
+	var cmds []tea.Cmd
+	if m.fetch.Enrich && item.Repository != nil {
+		cmds = append(cmds, fetchRepoContextCmd(m.api(), item.Repository.Name))
+	}
+	if item.Event != nil {
+		cmds = append(cmds, fetchReleaseCmd(m.api(), item.Event))
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderPager renders the detail view of pagerItem
+func (m *model) renderPager() {
+	item := m.pagerItem
+
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		var err error
+		width, height, err = term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			width, height = 80, 24
+		}
+	}
+
+	content := pagerContent(item, m.formatDate(item.CreatedAt))
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(glamourStyle()),
+		glamour.WithWordWrap(width-4),
+	)
+	if err == nil {
+		if out, err := renderer.Render(content); err == nil {
+			content = out
+		}
+	}
+	if item.Event != nil {
+		// glamour can't color the labels so they go above the rendered markdown
+		if meta, ok := eventIssueMeta(item.Event); ok && len(meta.Labels) > 0 {
+			content = "\n  " + labelBadges(meta.Labels) + "\n" + content
+		}
+	}
+	if item.Repository != nil {
+		if badges := repoContextBadges(item.Repository.Name); badges != "" {
+			content = "\n  " + badges + "\n" + content
+		}
+	}
+
+	m.pager = viewport.New(width, height-2)
+	m.pager.SetContent(content)
+}
+
+func (m model) updatePager(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case fetchReleaseMsg:
+		if msg.err != nil {
+			logger.Debug("fetching release", "error", msg.err)
+			return m, nil
+		}
+		m.renderPager()
+		return m, nil
+	case fetchRepoContextMsg:
+		if msg.err != nil {
+			logger.Debug("fetching repository language and topics", "error", msg.err)
+			return m, nil
+		}
+		if m.pagerItem.Repository != nil && m.pagerItem.Repository.Name == msg.repo {
+			m.renderPager()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "v":
+			m.showPager = false
+			return m, nil
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.pager, cmd = m.pager.Update(msg)
+	return m, cmd
+}
+
+func (m model) pagerView() string {
+	footer := fmt.Sprintf("  %3.f%% • ↑/↓ scroll • q/esc back", m.pager.ScrollPercent()*100)
+	return m.pager.View() + "\n" + pagerFooterStyle.Render(footer) + "\n"
+}
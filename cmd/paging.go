@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// lazyCount is how many events are fetched at a time when --count isn't set:
+	// the TUI starts with the newest and loads older ones as the cursor nears the bottom
+	lazyCount = 50
+	// prefetchRows is how close to the last row the cursor gets before loading more
+	prefetchRows = 5
+)
+
+// Message type for events fetched by loadMore
+type loadMoreMsg struct {
+	username string
+	received bool // for the received events tab of --me
+	fetchID  int
+	feed     *eventFeed
+	err      error
+}
+
+// loadMore fetches the events after the last ones shown, from where the previous
+// fetch stopped paging through the API
+func (m *model) loadMore() tea.Cmd {
+	if m.next.Page == 0 || m.loading || m.loadingMore {
+		return nil
+	}
+	m.loadingMore = true
+	m.setupTable() // add the loading row
+	opts := m.fetch
+	opts.From = m.next
+	opts.Count = cmp.Or(m.fetch.Count, lazyCount)
+	ctx, id, api := withQueueUser(m.fetchCtx, m.username), m.fetchID, m.api()
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		feed, err := fetchEvents(ctx, api, m.username, opts)
+		return loadMoreMsg{username: m.username, received: m.fetch.Received, fetchID: id, feed: feed, err: err}
+	})
+}
+
+// appendEvents adds the newly loaded events (skipping any already shown)
+func (m *model) appendEvents(msg loadMoreMsg) {
+	m.loadingMore = false
+	if msg.err != nil {
+		if !errors.Is(msg.err, context.Canceled) {
+			title, _ := describeFetchError(msg.err, m.username)
+			m.status = "loading more failed: " + strings.ToLower(title)
+		}
+		m.setupTable()
+		return
+	}
+	shown := make(map[string]bool, len(m.events))
+	for _, item := range m.events {
+		shown[item.Event.GetID()] = true
+	}
+	var added int
+	for _, item := range msg.feed.Items {
+		if item.Event != nil && shown[item.Event.GetID()] {
+			continue
+		}
+		m.events = append(m.events, item)
+		added++
+	}
+	m.next = msg.feed.Next
+	m.rate = msg.feed.Rate
+	sortEvents(m.events, m.fetch.Sort)
+	m.markUnread()
+	m.searchTexts = nil
+	m.updateSearchMatches()
+	m.setupTable()
+	if m.next.Page == 0 {
+		m.status = "no more events"
+	} else if added > 0 {
+		m.status = fmt.Sprintf("loaded %d more events", added)
+	}
+}
+
+// mergeNewEvents adds the events of an incremental refresh at the top, keeping the
+// cursor on the selected event
+func (m *model) mergeNewEvents(feed *eventFeed) {
+	var selected string
+	if cursor := m.table.Cursor(); cursor >= 0 && cursor < len(m.visible) && m.events[m.visible[cursor]].Event != nil {
+		selected = m.events[m.visible[cursor]].Event.GetID()
+	}
+	shown := make(map[string]bool, len(m.events))
+	for _, item := range m.events {
+		shown[item.Event.GetID()] = true
+	}
+	var added []eventItem
+	for _, item := range feed.Items {
+		if item.Event != nil && shown[item.Event.GetID()] {
+			continue
+		}
+		added = append(added, item)
+	}
+	m.etag = feed.ETag
+	m.cachedAt = time.Time{}
+	m.streak = feed.Streak
+	m.status = strings.Join(feed.Warnings, "; ")
+	if len(added) == 0 {
+		m.status = cmp.Or(m.status, "no new events")
+		return
+	}
+	m.events = append(added, m.events...)
+	sortEvents(m.events, m.fetch.Sort)
+	m.markUnread()
+	m.searchTexts = nil
+	m.updateSearchMatches()
+	m.setupTable()
+	if row := slices.IndexFunc(m.visible, func(i int) bool {
+		return selected != "" && m.events[i].Event.GetID() == selected
+	}); row >= 0 {
+		m.table.SetCursor(row)
+	}
+	m.status = cmp.Or(m.status, fmt.Sprintf("%d new events", len(added)))
+}
+
+// prefetch loads more events once the cursor is near the last row
+func (m *model) prefetch() tea.Cmd {
+	if len(m.visible) == 0 || len(m.visible)-m.table.Cursor() > prefetchRows {
+		return nil
+	}
+	return m.loadMore()
+}
+
+// fetchCount is the --count of a fetch, or when unset enough events for the
+// first screen (or to keep the ones loaded so far on a refresh)
+func (m model) fetchCount() int {
+	if m.fetch.Count > 0 || m.fetch.Offline || m.fetch.Replay != nil || m.fetch.Provider != nil {
+		// Only the API can be paged through lazily
+		return m.fetch.Count
+	}
+	return max(lazyCount, len(m.events))
+}
+
+// atBottom reports whether the key would move past the last row
+func (m model) atBottom(msg tea.KeyMsg) bool {
+	if m.table.Cursor() < len(m.table.Rows())-1 {
+		return false
+	}
+	return key.Matches(msg, m.keys.LineDown, m.keys.PageDown, m.keys.HalfPageDown, m.keys.GotoBottom)
+}
+
+// pageIndicator is the table page shown in the status bar (e.g. "page 2/5+"
+// when more events can be loaded)
+func (m model) pageIndicator() string {
+	rows := len(m.table.Rows())
+	perPage := max(m.table.Height(), 1)
+	indicator := fmt.Sprintf("page %d/%d", m.table.Cursor()/perPage+1, max((rows+perPage-1)/perPage, 1))
+	if m.next.Page > 0 {
+		indicator += "+"
+	}
+	return indicator
+}
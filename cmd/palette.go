@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+var (
+	paletteStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("63")).
+			Padding(0, 1)
+	paletteSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	paletteDimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// paletteCommand is an action that can be run from the command palette
+type paletteCommand struct {
+	Name string
+	Run  func(m *model) tea.Cmd
+	// Local commands act as the token's user or on the machine running gitfamous
+	// (or show other users than the session's), so they aren't offered over ssh
+	Local bool
+}
+
+var paletteCommands = []paletteCommand{
+	{Name: "open in browser", Run: func(m *model) tea.Cmd { m.handleEnterKey(); return nil }, Local: true},
+	{Name: "repo details", Run: func(m *model) tea.Cmd { return m.openRepo() }},
+	{Name: "push commits", Run: func(m *model) tea.Cmd { return m.openCommits() }},
+	{Name: "star/unstar repo", Run: func(m *model) tea.Cmd { return m.toggleStarCmd() }, Local: true},
+	{Name: "follow/unfollow user", Run: func(m *model) tea.Cmd { return m.toggleFollowCmd() }, Local: true},
+	{Name: "switch user", Run: func(m *model) tea.Cmd { return m.openUserPrompt() }, Local: true},
+	{Name: "bookmark event", Run: func(m *model) tea.Cmd { m.toggleBookmark(); return nil }, Local: true},
+	{Name: "show bookmarks", Run: func(m *model) tea.Cmd { m.openBookmarks(); return nil }, Local: true},
+	{Name: "pin/unpin repo", Run: func(m *model) tea.Cmd { m.togglePin(); return nil }, Local: true},
+	{Name: "mute repo", Run: func(m *model) tea.Cmd { m.muteRepo(); return nil }, Local: true},
+	{Name: "search events", Run: func(m *model) tea.Cmd { return m.openSearch() }},
+	{Name: "view details", Run: func(m *model) tea.Cmd { return m.openPager() }},
+	{Name: "load more events", Run: func(m *model) tea.Cmd { return m.loadMore() }},
+	{Name: "refresh", Run: func(m *model) tea.Cmd { return m.refetch() }},
+	{Name: "timeline", Run: func(m *model) tea.Cmd { m.openTimeline(); return nil }},
+	{Name: "toggle time format", Run: func(m *model) tea.Cmd { m.toggleTimeFormat(); return nil }},
+	{Name: "toggle group by repo", Run: func(m *model) tea.Cmd { m.toggleGroupByRepo(); return nil }},
+	{Name: "only this event type", Run: func(m *model) tea.Cmd { m.toggleTypeFilter(); return nil }},
+	{Name: "cycle sort order", Run: func(m *model) tea.Cmd { m.cycleSort(); return nil }},
+	{Name: "undo view change", Run: func(m *model) tea.Cmd { m.undoView(); return nil }},
+	{Name: "redo view change", Run: func(m *model) tea.Cmd { m.redoView(); return nil }},
+	{Name: "export json", Run: func(m *model) tea.Cmd { m.exportJSON(); return nil }, Local: true},
+	{Name: "help", Run: func(m *model) tea.Cmd { m.showHelp = true; return nil }},
+	{Name: "icon legend", Run: func(m *model) tea.Cmd { m.showLegend = true; return nil }},
+	{Name: "achievements", Run: func(m *model) tea.Cmd { return m.openAchievements() }},
+	{Name: "quit", Run: func(m *model) tea.Cmd { return tea.Quit }},
+}
+
+type paletteModel struct {
+	input    textinput.Model
+	matches  []int
+	cursor   int
+	readOnly bool // leave out the Local commands
+}
+
+func newPalette(readOnly bool) paletteModel {
+	ti := textinput.New()
+	ti.Prompt = ": "
+	ti.Placeholder = "type a command"
+	ti.Focus()
+	p := paletteModel{input: ti, readOnly: readOnly}
+	p.filter()
+	return p
+}
+
+// filter fuzzy matches the palette input against the command names
+func (p *paletteModel) filter() {
+	p.matches = p.matches[:0]
+	p.cursor = 0
+	var names []string
+	var indexes []int
+	for i, c := range paletteCommands {
+		if p.readOnly && c.Local {
+			continue
+		}
+		indexes = append(indexes, i)
+		names = append(names, c.Name)
+	}
+	query := strings.TrimSpace(p.input.Value())
+	if query == "" {
+		p.matches = append(p.matches, indexes...)
+		return
+	}
+	for _, match := range fuzzy.Find(query, names) {
+		p.matches = append(p.matches, indexes[match.Index])
+	}
+}
+
+func (m model) updatePalette(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			m.showPalette = false
+			return m, nil
+		case "up", "ctrl+p":
+			if m.palette.cursor > 0 {
+				m.palette.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.palette.cursor < len(m.palette.matches)-1 {
+				m.palette.cursor++
+			}
+			return m, nil
+		case "enter":
+			m.showPalette = false
+			if len(m.palette.matches) == 0 {
+				return m, nil
+			}
+			cmd := paletteCommands[m.palette.matches[m.palette.cursor]].Run(&m)
+			return m, cmd
+		}
+	}
+
+	var cmd tea.Cmd
+	m.palette.input, cmd = m.palette.input.Update(msg)
+	m.palette.filter()
+	return m, cmd
+}
+
+func (m model) paletteView() string {
+	var sb strings.Builder
+	sb.WriteString(m.palette.input.View())
+	sb.WriteString("\n")
+	if len(m.palette.matches) == 0 {
+		sb.WriteString(paletteDimStyle.Render("  no matching commands"))
+	}
+	for i, idx := range m.palette.matches {
+		name := "  " + paletteCommands[idx].Name
+		if i == m.palette.cursor {
+			name = paletteSelectedStyle.Render(name)
+		}
+		sb.WriteString("\n" + name)
+	}
+	return paletteStyle.Render(sb.String())
+}
+
+// exportJSON writes the currently displayed events to a JSON file in the working directory
+func (m *model) exportJSON() {
+	type exportedEvent struct {
+		CreatedAt   time.Time       `json:"created_at"`
+		Type        string          `json:"type"`
+		Actor       string          `json:"actor"`
+		Repository  string          `json:"repository"`
+		Description string          `json:"description"`
+		Payload     json.RawMessage `json:"payload,omitempty"`
+	}
+	var out []exportedEvent
+	for _, idx := range m.visible {
+		event := m.events[idx]
+		e := exportedEvent{
+			CreatedAt:   event.CreatedAt,
+			Type:        event.Type,
+			Actor:       event.Actor.Login,
+			Repository:  event.Repository.Name,
+			Description: event.Description,
+		}
+		if event.Event != nil {
+			e.Payload = event.Event.GetRawPayload()
+		}
+		out = append(out, e)
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		m.status = fmt.Sprintf("failed to export events: %v", err)
+		return
+	}
+	fname := fmt.Sprintf("gitfamous-%s-%s.json", m.username, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(fname, data, 0o644); err != nil {
+		m.status = fmt.Sprintf("failed to export events: %v", err)
+		return
+	}
+	m.status = fmt.Sprintf("exported %d events to %s", len(out), fname)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// pinnedMarker is shown in front of the events of pinned repositories
+var pinnedMarker = "📌 "
+
+// pinnedRepos are the repositories (lowercased) whose events are shown first, shared
+// by all tabs (pinned_repos in the config). The ssh sessions and config reloads use
+// them from their own goroutines, so they're guarded by pinsMu.
+var (
+	pinnedRepos = make(map[string]string)
+	pinsMu      sync.RWMutex
+)
+
+// pinRepos pins the repositories from the config
+func pinRepos(repos []string) {
+	pinsMu.Lock()
+	defer pinsMu.Unlock()
+	clear(pinnedRepos)
+	for _, repo := range repos {
+		pinnedRepos[strings.ToLower(repo)] = repo
+	}
+}
+
+// pinned reports whether repo is pinned
+func pinned(repo string) bool {
+	pinsMu.RLock()
+	defer pinsMu.RUnlock()
+	_, ok := pinnedRepos[strings.ToLower(repo)]
+	return ok
+}
+
+// anyPinned reports whether any repository is pinned
+func anyPinned() bool {
+	pinsMu.RLock()
+	defer pinsMu.RUnlock()
+	return len(pinnedRepos) > 0
+}
+
+// togglePinned pins or unpins repo and returns whether it's now pinned and all the pins
+func togglePinned(repo string) (bool, []string) {
+	pinsMu.Lock()
+	defer pinsMu.Unlock()
+	name := strings.ToLower(repo)
+	_, ok := pinnedRepos[name]
+	if ok {
+		delete(pinnedRepos, name)
+	} else {
+		pinnedRepos[name] = repo
+	}
+	return !ok, slices.Sorted(maps.Values(pinnedRepos))
+}
+
+// togglePin pins or unpins the repository of the selected event and saves the
+// pins to the config file
+func (m *model) togglePin() {
+	item, ok := m.selectedEvent()
+	if !ok || item.Repository.Name == "" {
+		return
+	}
+	now, pins := togglePinned(item.Repository.Name)
+	if now {
+		m.status = "pinned " + item.Repository.Name
+	} else {
+		m.status = "unpinned " + item.Repository.Name
+	}
+	if err := setConfigList(configPath, "pinned_repos", pins); err != nil {
+		m.status = fmt.Sprintf("failed to save pins: %v", err)
+	}
+	// Keep the cursor on the event as it moves with its repo
+	idx := m.visible[m.table.Cursor()]
+	m.setupTable()
+	if row := slices.Index(m.visible, idx); row >= 0 {
+		m.table.SetCursor(row)
+	}
+}
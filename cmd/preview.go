@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// previewLength caps the body previews in descriptions; the table truncates them further
+const previewLength = 200
+
+var (
+	htmlCommentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagRe     = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	codeFenceRe   = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	imageRe       = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkRe        = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	blockPrefixRe = regexp.MustCompile(`(?m)^\s*(#{1,6}\s+|>\s?|[-*+]\s+\[[ xX]\]\s+|[-*+]\s+|\d+\.\s+)`)
+	emphasisRe    = regexp.MustCompile("(\\*\\*|__|~~|`|\\*)")
+)
+
+// plainText strips the markdown and HTML from a comment or issue body and collapses
+// it to a single line so multi-line bodies don't break the table layout
+func plainText(md string) string {
+	s := htmlCommentRe.ReplaceAllString(md, "")
+	s = htmlTagRe.ReplaceAllString(s, " ")
+	s = codeFenceRe.ReplaceAllString(s, "")
+	s = imageRe.ReplaceAllString(s, "$1")
+	s = linkRe.ReplaceAllString(s, "$1")
+	s = blockPrefixRe.ReplaceAllString(s, "")
+	s = emphasisRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// previewText is plainText cut to previewLength runes
+func previewText(md string) string {
+	s := []rune(plainText(md))
+	if len(s) > previewLength {
+		return string(s[:previewLength-1]) + "…"
+	}
+	return string(s)
+}
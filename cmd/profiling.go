@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// pprofMode profiles the whole run (--pprof cpu|mem), see `go tool pprof`
+var pprofMode string
+
+// startProfile starts the --pprof profile and returns a func that writes it out
+func startProfile(mode string) (func(), error) {
+	switch mode {
+	case "":
+		return func() {}, nil
+	case "cpu":
+		f, err := os.Create("gitfamous-cpu.pprof")
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		return func() {
+			pprof.StopCPUProfile()
+			f.Close()
+			fmt.Fprintf(os.Stderr, "wrote CPU profile to %s\n", f.Name())
+		}, nil
+	case "mem":
+		return func() {
+			f, err := os.Create("gitfamous-mem.pprof")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to write memory profile: %v\n", err)
+				return
+			}
+			defer f.Close()
+			runtime.GC() // up to date allocation statistics
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to write memory profile: %v\n", err)
+				return
+			}
+			fmt.Fprintf(os.Stderr, "wrote memory profile to %s\n", f.Name())
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid --pprof %s (must be cpu or mem)", mode)
+}
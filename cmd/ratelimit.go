@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// apiRate is the --api-rate shared by all tabs in multi-user mode (requests per second)
+var apiRate float64
+
+// apiLimiter spaces out the API requests of all clients when set (see limitRequests)
+var apiLimiter *tokenBucket
+
+// limitRequests makes every client share one token bucket of rate requests per
+// second, so many tabs refreshing at once don't trip GitHub's secondary rate limits
+func limitRequests(rate float64) {
+	if rate <= 0 {
+		apiLimiter = nil
+		return
+	}
+	burst := max(rate, 1)
+	apiLimiter = &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// tokenBucket is a simple token bucket rate limiter
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // max tokens
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token that was reserved but not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens = min(b.burst, b.tokens+1)
+	b.mu.Unlock()
+}
+
+// queueKey is the context key of the user a request is made for
+type queueKey struct{}
+
+// withQueueUser tags the requests made with ctx as made for username, so the
+// tab bar can show which tabs are waiting on the rate limiter
+func withQueueUser(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, queueKey{}, username)
+}
+
+// apiQueue counts the requests waiting on apiLimiter by user
+var apiQueue = struct {
+	sync.Mutex
+	waiting map[string]int
+}{waiting: make(map[string]int)}
+
+func queueWait(username string, n int) {
+	apiQueue.Lock()
+	defer apiQueue.Unlock()
+	if apiQueue.waiting[username] += n; apiQueue.waiting[username] <= 0 {
+		delete(apiQueue.waiting, username)
+	}
+}
+
+// queuedMarker is shown in the tab bar with the number of requests a tab has waiting
+var queuedMarker = "⧗"
+
+// queued returns how many of a user's requests are waiting on the rate limiter
+func queued(username string) int {
+	apiQueue.Lock()
+	defer apiQueue.Unlock()
+	return apiQueue.waiting[username]
+}
+
+// limitTransport waits for a token from apiLimiter before each request
+type limitTransport struct {
+	base http.RoundTripper
+}
+
+func (t limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bucket := apiLimiter
+	if bucket == nil {
+		return t.base.RoundTrip(req)
+	}
+	if wait := bucket.reserve(); wait > 0 {
+		username, _ := req.Context().Value(queueKey{}).(string)
+		queueWait(username, 1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			queueWait(username, -1)
+			bucket.cancel()
+			return nil, req.Context().Err()
+		case <-timer.C:
+			queueWait(username, -1)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// dedupTransport shares the response of identical GET requests in flight, e.g.
+// when several tabs look up the same repository at the same time
+type dedupTransport struct {
+	base http.RoundTripper
+}
+
+// inFlight are the GET requests in flight across all clients
+var inFlight singleflight.Group
+
+// sharedResponse is a response with its body read so it can be handed out more than once
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+func (t dedupTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || apiLimiter == nil {
+		return t.base.RoundTrip(req)
+	}
+	key := req.URL.String() + "\x00" + req.Header.Get("Accept") + "\x00" + req.Header.Get("Authorization") + "\x00" + req.Header.Get("If-None-Match")
+	ch := inFlight.DoChan(key, func() (any, error) {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return sharedResponse{resp: resp, body: body}, nil
+	})
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case res := <-ch:
+		if res.Err != nil {
+			if res.Shared && errors.Is(res.Err, context.Canceled) && req.Context().Err() == nil {
+				// The request we joined was cancelled but this one wasn't
+				return t.base.RoundTrip(req)
+			}
+			return nil, res.Err
+		}
+		if res.Shared {
+			logger.Debug("api request shared", "url", req.URL.String())
+		}
+		shared := res.Val.(sharedResponse)
+		resp := *shared.resp
+		resp.Header = shared.resp.Header.Clone()
+		resp.Body = io.NopCloser(bytes.NewReader(shared.body))
+		resp.Request = req
+		return &resp, nil
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-github/v66/github"
+)
+
+// releaseCache keeps the releases fetched for the detail view, keyed by release ID
+var releaseCache sync.Map
+
+// Message type for a release fetched for the detail view
+type fetchReleaseMsg struct {
+	err error
+}
+
+// eventRelease returns the release of a ReleaseEvent, preferring the fetched copy
+// since event payloads can leave out the notes and assets
+func eventRelease(event *github.Event) (*github.RepositoryRelease, bool) {
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return nil, false
+	}
+	p, ok := payload.(*github.ReleaseEvent)
+	if !ok {
+		return nil, false
+	}
+	if rel, ok := releaseCache.Load(p.GetRelease().GetID()); ok {
+		return rel.(*github.RepositoryRelease), true
+	}
+	return p.GetRelease(), true
+}
+
+// fetchReleaseCmd fetches the full release of a ReleaseEvent for the detail view
+func fetchReleaseCmd(api eventsAPI, event *github.Event) tea.Cmd {
+	rel, ok := eventRelease(event)
+	if !ok || rel.GetID() == 0 {
+		return nil
+	}
+	if _, ok := releaseCache.Load(rel.GetID()); ok {
+		return nil
+	}
+	return func() tea.Msg {
+		owner, repo, ok := strings.Cut(event.GetRepo().GetName(), "/")
+		if !ok {
+			return fetchReleaseMsg{err: fmt.Errorf("invalid repository name: %s", event.GetRepo().GetName())}
+		}
+		full, _, err := api.GetRelease(context.Background(), owner, repo, rel.GetID())
+		if err != nil {
+			return fetchReleaseMsg{err: fmt.Errorf("failed to get release %s: %v", rel.GetTagName(), err)}
+		}
+		releaseCache.Store(rel.GetID(), full)
+		return fetchReleaseMsg{}
+	}
+}
+
+// releaseMarkdown is the tag, badges and assets section of a release in the detail view
+func releaseMarkdown(rel *github.RepositoryRelease) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Tag:** %s", rel.GetTagName())
+	if rel.GetPrerelease() {
+		sb.WriteString(" `prerelease`")
+	}
+	if rel.GetDraft() {
+		sb.WriteString(" `draft`")
+	}
+	sb.WriteString("  \n")
+	if len(rel.Assets) > 0 {
+		sb.WriteString("**Assets:**\n\n")
+		for _, asset := range rel.Assets {
+			fmt.Fprintf(&sb, "- [%s](%s) (%s, %d downloads)\n", asset.GetName(), asset.GetBrowserDownloadURL(),
+				humanize.Bytes(uint64(asset.GetSize())), asset.GetDownloadCount())
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
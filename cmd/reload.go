@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"path/filepath"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// configDebounce gives editors time to finish writing before we reload
+const configDebounce = 200 * time.Millisecond
+
+// configReloadMsg carries the users, filters, theme, pins and mutes from a reloaded config file
+type configReloadMsg struct {
+	users  []User
+	filter []string
+	theme  string
+	ignore []string // ignore_users plus --ignore-user
+	pins   []string
+	muted  []string
+	err    error
+}
+
+// watchConfig calls reload whenever the config file at path changes (other than by our own saves).
+// The parent directory is watched so editors that replace the file on save are picked up.
+func watchConfig(path string, reload func(*Config, error)) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(configDebounce, func() {
+					if savedByUs(path) {
+						// e.g. a pin or mute we saved, which is already shown
+						return
+					}
+					reload(reloadConfigFile(path))
+				})
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				reload(nil, err)
+			}
+		}
+	}()
+	return watcher, nil
+}
+
+// reloadConfig adds and removes tabs to match the reloaded config, applies the
+// theme, pins and mutes, and refetches the events of any user whose filters,
+// ignored users or mutes changed
+func (m multiUserModel) reloadConfig(msg configReloadMsg) (tea.Model, tea.Cmd) {
+	if msg.err == nil {
+		msg.err = setTheme(msg.theme)
+	}
+	if msg.err != nil {
+		m.tabs[m.active].status = "failed to reload config: " + msg.err.Error()
+		return m, nil
+	}
+	pinRepos(msg.pins)
+	active := m.tabs[m.active]
+
+	var tabs []model
+	var cmds []tea.Cmd
+	for _, user := range msg.users {
+		i := slices.IndexFunc(m.tabs, func(tab model) bool {
+			return tab.username == user.Name && tab.host == user.Host && tab.fetch.Received == m.fetch.Received
+		})
+		if i < 0 {
+			fetch := m.fetch
+			fetch.FilterTypes = msg.filter
+			fetch.IgnoreUsers = msg.ignore
+			fetch.MutedRepos = msg.muted
+			tab := initialModel(user, fetch, m.opts)
+			tab.keys.NextTab.SetEnabled(true)
+			tab.keys.PrevTab.SetEnabled(true)
+			if m.width > 0 {
+				updated, _ := tab.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height - tabBarHeight})
+				tab = updated.(model)
+			}
+			tabs = append(tabs, tab)
+			cmds = append(cmds, tab.Init())
+			continue
+		}
+		tab := m.tabs[i]
+		tab.apiToken, tab.fetch.Token = user.Token, user.Token
+		if !slices.Equal(tab.fetch.FilterTypes, msg.filter) || !slices.Equal(tab.fetch.IgnoreUsers, msg.ignore) ||
+			!slices.Equal(tab.fetch.MutedRepos, msg.muted) {
+			tab.fetch.FilterTypes = msg.filter
+			tab.fetch.IgnoreUsers = msg.ignore
+			tab.fetch.MutedRepos = msg.muted
+			tab.etag = ""
+			cmds = append(cmds, tab.refetch())
+		}
+		if len(tab.events) > 0 {
+			// Restyle and reorder the events for the theme and pins
+			tab.setupTable()
+		}
+		tabs = append(tabs, tab)
+	}
+	m.tabs = tabs
+	m.active = max(slices.IndexFunc(m.tabs, func(tab model) bool {
+		return tab.username == active.username && tab.host == active.host && tab.fetch.Received == active.fetch.Received
+	}), 0)
+	m.tabs[m.active].status = "config reloaded"
+	return m, tea.Batch(cmds...)
+}
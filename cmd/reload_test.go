@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReloadConfigMatchesReceivedTabs(t *testing.T) {
+	setupTestHome(t)
+	received := fetchOptions{Received: true}
+	tabs := []model{
+		initialModel(User{Name: "octocat"}, received, viewOptions{}),
+		initialModel(User{Name: "octocat"}, fetchOptions{}, viewOptions{}),
+	}
+	m := initialMultiUserModel(tabs, fetchOptions{}, viewOptions{})
+	m.active = 1
+
+	updated, _ := m.reloadConfig(configReloadMsg{users: []User{{Name: "octocat", Token: "new"}}})
+	m = updated.(multiUserModel)
+	if len(m.tabs) != 1 {
+		t.Fatalf("%d tabs after the reload, want 1", len(m.tabs))
+	}
+	if tab := m.tabs[0]; tab.fetch.Received || tab.apiToken != "new" {
+		t.Errorf("kept the tab of octocat's received events (token %q), want their own events with the new token", tab.apiToken)
+	}
+}
+
+func TestReloadConfigAppliesThemePinsAndMutes(t *testing.T) {
+	setupTestHome(t)
+	t.Cleanup(func() {
+		setTheme("")
+		pinRepos(nil)
+	})
+	tab := loadedModel(t, testEvents(), 100, 30)
+	m := initialMultiUserModel([]model{tab}, fetchOptions{}, viewOptions{})
+
+	updated, cmd := m.reloadConfig(configReloadMsg{
+		users:  []User{{Name: "octocat"}},
+		theme:  "dracula",
+		ignore: []string{"dependabot[bot]"},
+		pins:   []string{"blacktop/ipsw"},
+		muted:  []string{"octocat/hello-world"},
+	})
+	m = updated.(multiUserModel)
+	if currentTheme.Glamour != themes["dracula"].Glamour {
+		t.Error("the reloaded theme wasn't applied")
+	}
+	if !pinned("blacktop/ipsw") {
+		t.Error("the reloaded pins weren't applied")
+	}
+	fetch := m.tabs[0].fetch
+	if !slices.Equal(fetch.IgnoreUsers, []string{"dependabot[bot]"}) || !slices.Equal(fetch.MutedRepos, []string{"octocat/hello-world"}) {
+		t.Errorf("ignored users %q and muted repos %q after the reload", fetch.IgnoreUsers, fetch.MutedRepos)
+	}
+	if cmd == nil {
+		t.Error("changed mutes and ignored users didn't refetch the events")
+	}
+
+	updated, _ = m.reloadConfig(configReloadMsg{users: []User{{Name: "octocat"}}, theme: "nope"})
+	if got := updated.(multiUserModel).tabs[0].status; !strings.Contains(got, "unknown theme") {
+		t.Errorf("status = %q after reloading an unknown theme, want the error", got)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+var (
+	replayPath string
+	recordPath string
+)
+
+// fixture is a recording of users' events for --replay (written by --record)
+type fixture struct {
+	Users map[string]cachedEvents `json:"users"`
+}
+
+func loadFixture(path string) (*fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %v", path, err)
+	}
+	return &f, nil
+}
+
+// replayEvents serves a user's events from the --replay fixture instead of the API
+func replayEvents(username string, opts fetchOptions) (*eventFeed, error) {
+	recorded, ok := opts.Replay.Users[username]
+	if !ok {
+		return nil, fmt.Errorf("no events recorded for user %s in %s", username, replayPath)
+	}
+	logger.Debug("replaying events", "user", username, "events", len(recorded.Events))
+	selected, _ := selectEvents(recorded.Events, opts, nil)
+	items, err := toEventItems(username, selected, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &eventFeed{Items: items, FetchedAt: recorded.FetchedAt}, nil
+}
+
+// recordMu serializes writes to the --record fixture when several users are fetched at once
+var recordMu sync.Mutex
+
+// recordEvents adds the user's fetched events to the --record fixture
+func recordEvents(path, username string, events []*github.Event) error {
+	recordMu.Lock()
+	defer recordMu.Unlock()
+	f, err := loadFixture(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		f = &fixture{}
+	}
+	if f.Users == nil {
+		f.Users = make(map[string]cachedEvents)
+	}
+	f.Users[username] = cachedEvents{FetchedAt: time.Now(), Events: events}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	logger.Debug("recording events", "user", username, "events", len(events), "path", path)
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v66/github"
+)
+
+const (
+	enterActionBrowser = "browser"
+	enterActionRepo    = "repo"
+)
+
+var (
+	repoTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	repoLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+type repoInfo struct {
+	Name          string
+	Description   string
+	Stars         int
+	Forks         int
+	OpenIssues    int
+	OpenPRs       int
+	LatestRelease *github.RepositoryRelease
+	Events        []*github.Event
+}
+
+// Message type for a fetched repository
+type fetchRepoMsg struct {
+	username string
+	received bool
+	repo     *repoInfo
+	err      error
+}
+
+func fetchRepo(api eventsAPI, fullName string) (*repoInfo, error) {
+	ctx := context.Background()
+
+	owner, name, ok := strings.Cut(fullName, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name: %s", fullName)
+	}
+	repo, _, err := api.GetRepository(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository %s: %v", fullName, err)
+	}
+	info := &repoInfo{
+		Name:        repo.GetFullName(),
+		Description: repo.GetDescription(),
+		Stars:       repo.GetStargazersCount(),
+		Forks:       repo.GetForksCount(),
+	}
+
+	// open_issues_count includes pull requests
+	prs, _, err := api.SearchIssues(ctx, fmt.Sprintf("repo:%s type:pr state:open", fullName), &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pull requests for %s: %v", fullName, err)
+	}
+	info.OpenPRs = prs.GetTotal()
+	info.OpenIssues = max(repo.GetOpenIssuesCount()-info.OpenPRs, 0)
+
+	release, _, err := api.GetLatestRelease(ctx, owner, name)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to get latest release for %s: %v", fullName, err)
+		}
+	}
+	info.LatestRelease = release
+
+	info.Events, _, err = api.ListRepositoryEvents(ctx, owner, name, &github.ListOptions{PerPage: 10})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for %s: %v", fullName, err)
+	}
+
+	return info, nil
+}
+
+func (m *model) openRepo() tea.Cmd {
+	item, ok := m.selectedEvent()
+	if !ok {
+		return nil
+	}
+	m.showRepo = true
+	m.repo = nil
+	m.repoName = item.Repository.Name
+	api, username, received := m.api(), m.username, m.fetch.Received
+	return func() tea.Msg {
+		repo, err := fetchRepo(api, item.Repository.Name)
+		return fetchRepoMsg{username: username, received: received, repo: repo, err: err}
+	}
+}
+
+func (m model) updateRepo(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case fetchRepoMsg:
+		if msg.err != nil {
+			m.showRepo = false
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		m.repo = msg.repo
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "ctrl+c":
+			return m, tea.Quit
+		case msg.String() == "esc", msg.String() == "left", key.Matches(msg, m.keys.Quit):
+			m.showRepo = false
+		case key.Matches(msg, m.keys.Browser):
+			m.handleEnterKey()
+		}
+	}
+	return m, nil
+}
+
+func (m model) repoView() string {
+	if m.repo == nil {
+		return fmt.Sprintf("Loading %s...\n", m.repoName)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(repoTitleStyle.Render(m.repo.Name) + "\n")
+	if m.repo.Description != "" {
+		sb.WriteString(m.repo.Description + "\n")
+	}
+	sb.WriteString("\n")
+	stat := func(label string, value any) string {
+		return repoLabelStyle.Render(label+" ") + fmt.Sprint(value)
+	}
+	sb.WriteString(strings.Join([]string{
+		stat("⭐️ stars", m.repo.Stars),
+		stat(" forks", m.repo.Forks),
+		stat("󱋄 open issues", m.repo.OpenIssues),
+		stat(" open PRs", m.repo.OpenPRs),
+	}, "   ") + "\n")
+	if rel := m.repo.LatestRelease; rel != nil {
+		name := rel.GetName()
+		if name == "" {
+			name = rel.GetTagName()
+		}
+		sb.WriteString(stat("󰎔 latest release", fmt.Sprintf("%s (%s)", name, humanTime(rel.GetPublishedAt().Time))) + "\n")
+	}
+
+	sb.WriteString("\n" + repoTitleStyle.Render("Recent events") + "\n")
+	if len(m.repo.Events) == 0 {
+		sb.WriteString(repoLabelStyle.Render("no recent events") + "\n")
+	}
+	line := lipgloss.NewStyle().MaxWidth(m.termWidth() - 4)
+	for _, event := range m.repo.Events {
+		sb.WriteString(line.Render(fmt.Sprintf("%s  %-16s %s",
+			repoLabelStyle.Render(fmt.Sprintf("%-14s", humanTime(event.GetCreatedAt().Time))),
+			event.GetActor().GetLogin(),
+			getEventDescription(event))) + "\n")
+	}
+
+	return baseTableStyle.Padding(0, 1).Render(sb.String()) + "\n  o open in browser • esc/← back\n"
+}
@@ -22,7 +22,11 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"regexp"
 	"slices"
@@ -33,7 +37,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/expr-lang/expr/vm"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
@@ -41,8 +47,31 @@ var (
 	verbose     bool
 	githubToken string
 	eventCount  int
+	perPage     int
 	since       string
+	until       string
 	filterTypes []string // New variable for the filter flag
+	ignoreUsers []string
+	exprSource  string
+	grepPattern string
+	grepInvert  string
+	timeFormat  string
+	useUTC      bool
+	configPath  string
+	accountName string
+	profileName string
+	splitView   bool
+	enterAction string
+	repoColumn  string
+	typeColumn  bool
+	coalesce    bool
+	offline     bool
+	private     bool
+
+	retryAttempts int
+	retryBackoff  time.Duration
+	proxyURL      string
+	caCertPath    string
 )
 
 // Define a list of valid event types
@@ -67,43 +96,295 @@ var validEventTypes = []string{
 	// Add other event types as needed
 }
 
+// filterAliases maps friendly --filter names (and groups) onto event types
+var filterAliases = map[string][]string{
+	"push":           {"PushEvent"},
+	"pr":             {"PullRequestEvent"},
+	"issue":          {"IssuesEvent"},
+	"comment":        {"IssueCommentEvent", "CommitCommentEvent", "PullRequestReviewCommentEvent"},
+	"review":         {"PullRequestReviewEvent", "PullRequestReviewCommentEvent", "PullRequestReviewThreadEvent"},
+	"star":           {"WatchEvent"},
+	"fork":           {"ForkEvent"},
+	"release":        {"ReleaseEvent"},
+	"create":         {"CreateEvent"},
+	"delete":         {"DeleteEvent"},
+	"wiki":           {"GollumEvent"},
+	"member":         {"MemberEvent"},
+	"public":         {"PublicEvent"},
+	"sponsor":        {"SponsorshipEvent"},
+	"commit-comment": {"CommitCommentEvent"},
+	// Groups
+	"code":   {"PushEvent", "PullRequestEvent", "PullRequestReviewEvent", "PullRequestReviewCommentEvent", "PullRequestReviewThreadEvent", "CommitCommentEvent", "CreateEvent", "DeleteEvent", "ReleaseEvent"},
+	"social": {"WatchEvent", "ForkEvent", "MemberEvent", "PublicEvent", "SponsorshipEvent"},
+}
+
+// parseFilterTypes expands --filter aliases into event types, erroring on unknown types
+func parseFilterTypes(filters []string) ([]string, error) {
+	var types []string
+	for _, f := range filters {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		expanded, ok := filterAliases[strings.ToLower(f)]
+		if !ok {
+			i := slices.IndexFunc(validEventTypes, func(t string) bool {
+				return strings.EqualFold(t, f) || strings.EqualFold(t, f+"Event")
+			})
+			if i < 0 {
+				return nil, fmt.Errorf("invalid event type in --filter: %s", f)
+			}
+			expanded = []string{validEventTypes[i]}
+		}
+		for _, t := range expanded {
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	return types, nil
+}
+
+var (
+	// durationRe matches a single duration component like '1w', '2d', '3h' or '1mo'
+	durationRe = regexp.MustCompile(`(\d+)(mo|[smhdwy])`)
+	// compoundDurationRe matches a whole duration made of one or more components
+	compoundDurationRe = regexp.MustCompile(`^(\d+(mo|[smhdwy]))+$`)
+)
+
+// parseExtendedDuration parses durations like '1w', '1mo' or compound ones like '1w3d'
 func parseExtendedDuration(input string) (time.Duration, error) {
-	// Regular expression to match duration strings like '1w', '2d', '3h'
-	re := regexp.MustCompile(`^(\d+)([smhdw])$`)
-	matches := re.FindStringSubmatch(strings.TrimSpace(input))
-	if len(matches) != 3 {
+	input = strings.TrimSpace(input)
+	if !compoundDurationRe.MatchString(input) {
 		return 0, fmt.Errorf("invalid duration: %s", input)
 	}
 
-	value, err := strconv.Atoi(matches[1])
+	var duration time.Duration
+	for _, matches := range durationRe.FindAllStringSubmatch(input, -1) {
+		value, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid number in duration: %v", err)
+		}
+
+		unit := matches[2]
+		switch unit {
+		case "s":
+			duration += time.Duration(value) * time.Second
+		case "m":
+			duration += time.Duration(value) * time.Minute
+		case "h":
+			duration += time.Duration(value) * time.Hour
+		case "d":
+			duration += time.Duration(value) * time.Hour * 24
+		case "w":
+			duration += time.Duration(value) * time.Hour * 24 * 7
+		case "mo":
+			duration += time.Duration(value) * time.Hour * 24 * 30
+		case "y":
+			duration += time.Duration(value) * time.Hour * 24 * 365
+		default:
+			return 0, fmt.Errorf("unknown unit in duration: %s", unit)
+		}
+	}
+	return duration, nil
+}
+
+// timeBound is a point in time given either as an absolute date or as a
+// duration relative to now (resolved when it is used)
+type timeBound struct {
+	abs time.Time
+	rel time.Duration
+}
+
+// absoluteLayouts are the date formats accepted by --since/--until
+var absoluteLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func parseTimeBound(input string) (timeBound, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return timeBound{}, nil
+	}
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, input, time.Local); err == nil {
+			return timeBound{abs: t}, nil
+		}
+	}
+	d, err := parseExtendedDuration(input)
 	if err != nil {
-		return 0, fmt.Errorf("invalid number in duration: %v", err)
+		return timeBound{}, fmt.Errorf("invalid time %q: expected a duration (e.g. 1d) or a date (e.g. 2024-01-01)", input)
 	}
+	return timeBound{rel: d}, nil
+}
 
-	unit := matches[2]
-	var duration time.Duration
-	switch unit {
-	case "s":
-		duration = time.Duration(value) * time.Second
-	case "m":
-		duration = time.Duration(value) * time.Minute
-	case "h":
-		duration = time.Duration(value) * time.Hour
-	case "d":
-		duration = time.Duration(value) * time.Hour * 24
-	case "w":
-		duration = time.Duration(value) * time.Hour * 24 * 7
-	default:
-		return 0, fmt.Errorf("unknown unit in duration: %s", unit)
+// IsZero reports whether the bound is unset
+func (b timeBound) IsZero() bool {
+	return b.abs.IsZero() && b.rel == 0
+}
+
+// Time resolves the bound against the current time
+func (b timeBound) Time() time.Time {
+	if b.rel > 0 {
+		return time.Now().Add(-b.rel)
 	}
-	return duration, nil
+	return b.abs
+}
+
+func (b timeBound) String() string {
+	switch {
+	case b.rel > 0:
+		return b.rel.String()
+	case !b.abs.IsZero():
+		return b.abs.Format(time.RFC3339)
+	}
+	return "forever"
+}
+
+// applyProfile overlays the selected profile onto the config and any flags not given on the command line
+func applyProfile(conf *Config) error {
+	if profileName == "" {
+		return nil
+	}
+	prof, ok := conf.Profiles[profileName]
+	if !ok {
+		return fmt.Errorf("unknown profile %s", profileName)
+	}
+	if len(prof.Users) > 0 {
+		conf.Users = prof.Users
+	}
+	if len(prof.Filter) > 0 {
+		conf.Filter = prof.Filter
+	}
+	if accountName == "" {
+		accountName = prof.Account
+	}
+	if since == "" {
+		since = prof.Since
+	}
+	if until == "" {
+		until = prof.Until
+	}
+	if eventCount == 0 {
+		eventCount = prof.Count
+	}
+	return nil
+}
+
+// resolveUsers works out which users to track and their tokens from the
+// command line args, the config file and the selected account
+func resolveUsers(conf *Config, args []string) ([]User, error) {
+	// Usernames given on the command line take precedence over the config file
+	users := conf.Users
+	if len(args) > 0 {
+		users = nil
+		for _, arg := range args {
+			users = append(users, User{Name: arg, Account: accountName})
+		}
+	} else if accountName != "" {
+		// Only track the configured users belonging to the selected account
+		users = slices.DeleteFunc(slices.Clone(users), func(u User) bool {
+			return u.Account != accountName
+		})
+	}
+	if len(users) == 0 {
+		// Fall back to the account's default user
+		acct, err := conf.account(accountName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load account: %v", err)
+		}
+		if acct.User != "" {
+			users = append(users, User{Name: acct.User, Account: accountName})
+		} else if token := cmp.Or(acct.Token, githubToken); token != "" && !offline && replayPath == "" {
+			// Show the token's own feed
+			login, err := viewerLogin(context.Background(), token, acct.Host)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the user of the token: %v", err)
+			}
+			users = append(users, User{Name: login, Account: accountName})
+		}
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("a username argument, a config file with users or a token (to show its own user) is required")
+	}
+	users = slices.Clone(users)
+	for i, user := range users {
+		acct, err := conf.account(user.Account)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load account for user %s: %v", user.Name, err)
+		}
+		users[i].Host = acct.Host
+		if user.Token == "" {
+			users[i].Token = acct.Token
+		}
+		if users[i].Token == "" {
+			users[i].Token = githubToken
+		}
+		if users[i].Token == "" && !offline && replayPath == "" {
+			return nil, fmt.Errorf("Github API token is required")
+		}
+	}
+	return users, nil
+}
+
+// setupSubcommand loads the config and resolves the users for the subcommands that run without the TUI
+func setupSubcommand(args []string) (*Config, []User, error) {
+	conf, err := setupConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	users, err := resolveUsers(conf, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conf, users, nil
+}
+
+// setupConfig loads the config for the subcommands that run without the TUI
+func setupConfig() (*Config, error) {
+	if verbose {
+		logger.SetLevel(log.DebugLevel)
+	}
+	if githubToken == "" {
+		githubToken = os.Getenv("GITHUB_TOKEN")
+		if githubToken == "" {
+			githubToken = os.Getenv("GITHUB_API_TOKEN")
+		}
+	}
+	conf, err := loadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := compileTemplates(conf.Templates); err != nil {
+		return nil, err
+	}
+	if err := configureTransport(conf.Proxy, conf.CACert); err != nil {
+		return nil, err
+	}
+	return conf, nil
 }
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "gitfamous <username>",
+	Use:   "gitfamous [username...]",
 	Short: "Github Event Tracker TUI",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.ArbitraryArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		closeLog, err := setupLogging()
+		if err != nil {
+			return fmt.Errorf("failed to open --log-file: %v", err)
+		}
+		cobra.OnFinalize(func() { closeLog() })
+		stopProfile, err := startProfile(pprofMode)
+		if err != nil {
+			return err
+		}
+		cobra.OnFinalize(stopProfile)
+		return setLocale(langFlag)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if verbose {
 			log.SetLevel(log.DebugLevel)
@@ -115,30 +396,275 @@ var rootCmd = &cobra.Command{
 				githubToken = os.Getenv("GITHUB_API_TOKEN")
 			}
 		}
-		if githubToken == "" {
-			logger.Error("Github API token is required")
+		conf, err := loadConfig(configPath)
+		if err != nil {
+			logger.Error("loading config", "error", err)
 			os.Exit(1)
 		}
-		var sinceDuration time.Duration
-		if since == "" {
-			sinceDuration = 0
-		} else {
-			var err error
-			sinceDuration, err = parseExtendedDuration(since)
+		if _, err := os.Stat(configPath); configPath != "" && errors.Is(err, os.ErrNotExist) && len(args) == 0 && githubToken == "" &&
+			profileName == "" && accountName == "" && replayPath == "" && !accessible && term.IsTerminal(int(os.Stdin.Fd())) {
+			// First run: ask for the basics and write a config
+			saved, err := runSetupWizard(configPath)
+			if err != nil {
+				logger.Error("running setup wizard", "error", err)
+				os.Exit(1)
+			}
+			if !saved {
+				return
+			}
+			logger.Info("saved config", "path", configPath)
+			if conf, err = loadConfig(configPath); err != nil {
+				logger.Error("loading config", "error", err)
+				os.Exit(1)
+			}
+		}
+		if err := setTheme(cmp.Or(themeFlag, conf.Theme)); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		if err := setLayout(conf.Layout); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		setTableStyle(conf.TableStyle)
+		setupConsole()
+		browserCommand = cmp.Or(os.Getenv("GITFAMOUS_BROWSER"), conf.Browser)
+		if err := compileTemplates(conf.Templates); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		if err := applyProfile(conf); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		if proxyURL == "" {
+			proxyURL = conf.Proxy
+		}
+		if caCertPath == "" {
+			caCertPath = conf.CACert
+		}
+		if err := configureTransport(proxyURL, caCertPath); err != nil {
+			logger.Error("configuring HTTP transport", "error", err)
+			os.Exit(1)
+		}
+		var replay *fixture
+		if replayPath != "" {
+			if offline || recordPath != "" {
+				logger.Error("--replay can't be combined with --offline or --record")
+				os.Exit(1)
+			}
+			if replay, err = loadFixture(replayPath); err != nil {
+				logger.Error("loading --replay fixture", "error", err)
+				os.Exit(1)
+			}
+			if len(args) == 0 {
+				// Show everyone in the fixture
+				args = slices.Sorted(maps.Keys(replay.Users))
+			}
+		}
+		var users []User
+		if meMode {
+			if len(args) > 0 {
+				logger.Error("--me shows the token's own user, so it takes no usernames")
+				os.Exit(1)
+			}
+			user, err := meUser(conf)
+			if err != nil {
+				logger.Error(err)
+				os.Exit(1)
+			}
+			users = []User{user}
+			private = true
+		} else if users, err = resolveUsers(conf, args); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		if perPage < 1 || perPage > 100 {
+			logger.Error("--per-page must be between 1 and 100", "per-page", perPage)
+			os.Exit(1)
+		}
+		sinceBound, err := parseTimeBound(since)
+		if err != nil {
+			logger.Error("parsing --since", "error", err)
+			os.Exit(1)
+		}
+		untilBound, err := parseTimeBound(until)
+		if err != nil {
+			logger.Error("parsing --until", "error", err)
+			os.Exit(1)
+		}
+		if !sinceBound.IsZero() && !untilBound.IsZero() && !untilBound.Time().After(sinceBound.Time()) {
+			logger.Error("--until must be after --since")
+			os.Exit(1)
+		}
+		if len(filterTypes) == 0 {
+			filterTypes = conf.Filter
+		}
+		filterTypes, err = parseFilterTypes(filterTypes)
+		if err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+
+		// --ignore-user adds to ignore_users, which is reapplied on config reloads
+		ignore := append(slices.Clip(ignoreUsers), conf.IgnoreUsers...)
+		pinRepos(conf.PinnedRepos)
+		var grep, grepV *regexp.Regexp
+		if grepPattern != "" {
+			if grep, err = regexp.Compile(grepPattern); err != nil {
+				logger.Error("invalid --grep", "error", err)
+				os.Exit(1)
+			}
+		}
+		if grepInvert != "" {
+			if grepV, err = regexp.Compile(grepInvert); err != nil {
+				logger.Error("invalid --grep-v", "error", err)
+				os.Exit(1)
+			}
+		}
+
+		var program *vm.Program
+		if exprSource != "" {
+			if program, err = compileExpr(exprSource); err != nil {
+				logger.Error(err)
+				os.Exit(1)
+			}
+		}
+
+		if enterAction == "" {
+			enterAction = conf.EnterAction
+		}
+		switch enterAction {
+		case "":
+			enterAction = enterActionBrowser
+		case enterActionBrowser, enterActionRepo:
+		default:
+			logger.Error("invalid --enter-action (must be 'browser' or 'repo')", "action", enterAction)
+			os.Exit(1)
+		}
+		repoColumn = cmp.Or(repoColumn, conf.RepoColumn, repoColumnFull)
+		if !slices.Contains(repoColumns, repoColumn) {
+			logger.Error("invalid --repo-column (must be 'full', 'split' or 'short')", "column", repoColumn)
+			os.Exit(1)
+		}
+		if err := checkSort(sortBy); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		opts := viewOptions{
+			TimeFormat:  timeFormat,
+			UTC:         useUTC,
+			EnterAction: enterAction,
+			RepoColumn:  repoColumn,
+			TypeColumn:  typeColumn || conf.TypeColumn,
+			Sparklines:  sparklines,
+		}
+		fetch := fetchOptions{
+			Count:       eventCount,
+			Since:       sinceBound,
+			Until:       untilBound,
+			FilterTypes: filterTypes,
+			IgnoreUsers: ignore,
+			MutedRepos:  conf.MutedRepos,
+			Grep:        grep,
+			GrepV:       grepV,
+			Expr:        program,
+			Coalesce:    coalesce,
+			Offline:     offline,
+			Replay:      replay,
+			Record:      recordPath,
+			PerPage:     perPage,
+			Enrich:      enrich,
+			CIStatus:    ciStatusFlag,
+			Sort:        sortBy,
+
+			IncludePrivate: private,
+		}
+
+		if estimate {
+			enough, err := printEstimate(os.Stdout, users, fetch)
 			if err != nil {
-				logger.Error("parsing since duration", "error", err)
+				logger.Error(err)
+				os.Exit(1)
+			}
+			if !enough {
 				os.Exit(1)
 			}
+			return
 		}
-		for _, f := range filterTypes {
-			if !slices.Contains(validEventTypes, f) {
-				logger.Warn("Invalid event type in --filter:", f)
+
+		if accessible {
+			if err := printAccessible(os.Stdout, users, fetch, opts); err != nil {
+				logger.Error(err)
+				os.Exit(1)
 			}
+			return
+		}
+
+		var tabs []model
+		for _, user := range users {
+			tabs = append(tabs, initialModel(user, fetch, opts))
+		}
+		if meMode && !offline && replay == nil {
+			// Own events with the notification count, then what they received
+			self := opts
+			self.Notifications = true
+			received := fetch
+			received.Received = true
+			tabs = []model{initialModel(users[0], fetch, self), initialModel(users[0], received, opts)}
+		}
+		var tm tea.Model = tabs[0]
+		switch {
+		case splitView:
+			if len(tabs) != 2 {
+				logger.Error("--split requires exactly two users")
+				os.Exit(1)
+			}
+			tm = initialSplitModel(tabs[0], tabs[1])
+		case len(tabs) > 1:
+			tm = initialMultiUserModel(tabs, fetch, opts)
+			limitRequests(apiRate)
 		}
 
 		// Start the TUI application
-		p := tea.NewProgram(initialModel(args[0], githubToken, eventCount, sinceDuration, filterTypes), tea.WithAltScreen())
-		// p := tea.NewProgram(initialModel(args[0], githubToken))
+		p := tea.NewProgram(tm, tea.WithAltScreen(), tea.WithMouseCellMotion())
+		if _, ok := tm.(multiUserModel); ok && configPath != "" && !meMode {
+			// Pick up config changes without restarting
+			watcher, err := watchConfig(configPath, func(conf *Config, err error) {
+				if err == nil {
+					err = applyProfile(conf)
+				}
+				if err != nil {
+					p.Send(configReloadMsg{err: err})
+					return
+				}
+				users, err := resolveUsers(conf, args)
+				if err != nil {
+					p.Send(configReloadMsg{err: err})
+					return
+				}
+				filter := fetch.FilterTypes
+				if !cmd.Flags().Changed("filter") {
+					if filter, err = parseFilterTypes(conf.Filter); err != nil {
+						p.Send(configReloadMsg{err: err})
+						return
+					}
+				}
+				p.Send(configReloadMsg{
+					users:  users,
+					filter: filter,
+					theme:  cmp.Or(themeFlag, conf.Theme),
+					ignore: append(slices.Clip(ignoreUsers), conf.IgnoreUsers...),
+					pins:   conf.PinnedRepos,
+					muted:  conf.MutedRepos,
+				})
+			})
+			if err != nil {
+				logger.Debug("watching config", "error", err)
+			} else {
+				defer watcher.Close()
+			}
+		}
 		if m, err := p.Run(); err != nil {
 			logger.Error("running gitfamous", "error", err)
 			os.Exit(1)
@@ -178,7 +704,57 @@ func init() {
 	// Define CLI flags
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
 	rootCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
-	rootCmd.Flags().IntVarP(&eventCount, "count", "c", 0, "Number of events to fetch")
-	rootCmd.Flags().StringVarP(&since, "since", "s", "", "Limit events to those after the specified amount of time (e.g. 1h, 1d, 1w)")
-	rootCmd.Flags().StringSliceVarP(&filterTypes, "filter", "f", nil, "Comma-separated list of event types to display")
+	rootCmd.Flags().StringVar(&enterAction, "enter-action", "", "What enter does on a row: 'browser' (default) or 'repo' for an in-TUI repository view")
+	rootCmd.Flags().StringVar(&repoColumn, "repo-column", "", "How to show repositories: 'full' owner/repo (default), 'split' into Owner and Repo columns or 'short' without the owner on the user's own repos")
+	rootCmd.Flags().BoolVar(&typeColumn, "type-column", false, "Show the event icons in a Type column of their own rather than in the description")
+	rootCmd.Flags().BoolVar(&meMode, "me", false, "Show the token's own user: private events too (with the 'repo' scope), received events in a second tab and unread notifications")
+	rootCmd.Flags().BoolVar(&splitView, "split", false, "Show two users side by side in split panes")
+	rootCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use (host, token and default user)")
+	rootCmd.Flags().StringVarP(&profileName, "profile", "p", "", "Named profile (saved users, filters and time range) from the config file")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", defaultConfigPath(), "Config file with users to track")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write debug logs (API requests, pagination, cache use and render timings) to a file")
+	rootCmd.PersistentFlags().BoolVar(&traceHTTP, "trace-http", false, "Also log the headers of every API request and response to --log-file (tokens redacted)")
+	rootCmd.PersistentFlags().StringVar(&pprofMode, "pprof", "", "Write a cpu or mem profile of the run to gitfamous-<mode>.pprof (see --log-file for timings)")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language of relative dates: en, de, es, fr or pt (default from $LANG)")
+	rootCmd.Flags().StringVar(&proxyURL, "proxy", "", "HTTP(S) proxy URL for API requests")
+	rootCmd.Flags().StringVar(&caCertPath, "ca-cert", "", "PEM file with extra CA certificates to trust (e.g. for a corporate proxy)")
+	rootCmd.Flags().IntVar(&retryAttempts, "retries", 3, "Number of attempts for API requests failing with transient errors")
+	rootCmd.Flags().DurationVar(&retryBackoff, "retry-backoff", time.Second, "Initial backoff between retries (doubles on each attempt)")
+	rootCmd.Flags().Float64Var(&apiRate, "api-rate", 5, "Max API requests per second shared by all tabs when tracking several users (0 for no limit)")
+	rootCmd.Flags().IntVarP(&eventCount, "count", "c", 0, "Number of events to fetch (default: the newest, then older ones as you scroll down)")
+	rootCmd.Flags().IntVar(&perPage, "per-page", 100, "Number of events to request per API page (max 100)")
+	rootCmd.Flags().StringVarP(&since, "since", "s", "", "Limit events to those after the specified amount of time or date (e.g. 1h, 1w3d, 1mo, 2024-01-01)")
+	rootCmd.Flags().StringVarP(&until, "until", "u", "", "Limit events to those before the specified amount of time or date (e.g. 1d, 2024-02-01)")
+	rootCmd.Flags().StringSliceVarP(&filterTypes, "filter", "f", nil, "Comma-separated list of event types or aliases to display (e.g. push,pr,issue,star,release or the groups code,social)")
+	rootCmd.Flags().StringSliceVar(&ignoreUsers, "ignore-user", nil, "Hide the events of these actors, e.g. bots or mirrors (added to ignore_users from the config)")
+	rootCmd.Flags().StringVar(&grepPattern, "grep", "", "Only show events whose description or raw payload matches a regexp (e.g. CVE or '(?i)feature/')")
+	rootCmd.Flags().StringVar(&grepInvert, "grep-v", "", "Hide events whose description or raw payload matches a regexp")
+	rootCmd.Flags().StringVar(&exprSource, "expr", "", `Only show events matching an expression over type, repo, actor, description, action, ref, public, created_at and payload (e.g. 'type == "PushEvent" && repo =~ "ipsw"')`)
+	rootCmd.Flags().BoolVar(&private, "include-private", false, "Include private events (requires the user's own token with the 'repo' scope)")
+	rootCmd.Flags().BoolVar(&offline, "offline", false, "Show the most recently cached events instead of fetching from the API")
+	rootCmd.Flags().StringVar(&replayPath, "replay", "", "Load events from a fixture recorded with --record instead of the API (no token needed)")
+	rootCmd.Flags().StringVar(&recordPath, "record", "", "Save the fetched events to a fixture file for --replay")
+	rootCmd.Flags().BoolVar(&hyperlinks, "hyperlinks", supportsHyperlinks(), "Make repositories and descriptions clickable OSC 8 links to the event (default on in terminals known to support them)")
+	rootCmd.Flags().StringVar(&themeFlag, "theme", "", "Color theme: 'auto' follows the terminal background (default), 'dark', 'light', 'dracula', 'nord' or 'gruvbox'")
+	rootCmd.Flags().BoolVar(&forceASCII, "ascii", false, "Draw ASCII borders and markers without icons (automatic on legacy Windows consoles)")
+	rootCmd.Flags().BoolVar(&accessible, "accessible", os.Getenv("ACCESSIBLE") != "", "Print plain labelled lines for screen readers instead of the TUI (no alt screen, colors or icons; or set $ACCESSIBLE)")
+	rootCmd.Flags().BoolVar(&enrich, "enrich", false, "Fetch the size (+additions −deletions) of pushes and pull requests, and the language and topics of repositories in the detail view (extra API requests)")
+	rootCmd.Flags().BoolVar(&ciStatusFlag, "ci", false, "Show the CI status of pushes and pull requests (✓ passed, ✗ failed, ● pending; extra API requests)")
+	rootCmd.Flags().StringVar(&sortBy, "sort", "date", "Order events by date, repo, type or actor (newest first within each)")
+	rootCmd.Flags().BoolVar(&sparklines, "sparklines", false, "Show a sparkline of each repository's activity in the fetched events, to spot the busiest repos")
+	rootCmd.Flags().BoolVar(&estimate, "estimate", false, "Print how many API requests the run would take and whether the rate limit left suffices, without fetching")
+	rootCmd.Flags().BoolVar(&coalesce, "coalesce", false, "Summarize bursts of similar consecutive events into single rows")
+	rootCmd.Flags().StringVar(&timeFormat, "time-format", "relative", "Timestamp format: relative, rfc3339 or a custom Go time layout (toggle with 't')")
+	rootCmd.Flags().BoolVar(&useUTC, "utc", false, "Display timestamps in UTC instead of the local timezone")
+	// Flag completions (see completion.go)
+	rootCmd.RegisterFlagCompletionFunc("filter", completeFilter)
+	rootCmd.RegisterFlagCompletionFunc("account", completeAccount)
+	rootCmd.RegisterFlagCompletionFunc("profile", completeProfile)
+	rootCmd.RegisterFlagCompletionFunc("enter-action", cobra.FixedCompletions([]string{enterActionBrowser, enterActionRepo}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("theme", cobra.FixedCompletions(themeNames(), cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("repo-column", cobra.FixedCompletions(repoColumns, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(sortModes, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("pprof", cobra.FixedCompletions([]string{"cpu", "mem"}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("lang", cobra.FixedCompletions(langNames(), cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("time-format", cobra.FixedCompletions([]string{timeFormatRelative, timeFormatRFC3339}, cobra.ShellCompDirectiveNoFileComp))
 }
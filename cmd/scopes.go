@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// tokenLogin is the login and OAuth scopes a token belongs to
+type tokenLogin struct {
+	login  string
+	scopes []string
+}
+
+// tokenLogins caches the tokenScopes of each token (by host and token)
+var tokenLogins sync.Map
+
+// tokenScopes returns the authenticated user's login and the OAuth scopes of the
+// token (nil for fine-grained tokens, which don't report scopes); they're only looked
+// up once per token, an empty token (e.g. a stubbed API) every time
+func tokenScopes(ctx context.Context, api eventsAPI, token, host string) (string, []string, error) {
+	key := host + "\x00" + token
+	if cached, ok := tokenLogins.Load(key); ok {
+		tl := cached.(tokenLogin)
+		return tl.login, tl.scopes, nil
+	}
+	user, resp, err := api.GetUser(ctx, "")
+	if err != nil {
+		return "", nil, err
+	}
+	var scopes []string
+	if header := resp.Header.Get("X-OAuth-Scopes"); header != "" {
+		for _, scope := range strings.Split(header, ",") {
+			scopes = append(scopes, strings.TrimSpace(scope))
+		}
+	}
+	if token != "" {
+		tokenLogins.Store(key, tokenLogin{login: user.GetLogin(), scopes: scopes})
+	}
+	return user.GetLogin(), scopes, nil
+}
+
+// canSeePrivateEvents reports whether the token can list username's private events,
+// along with a warning explaining why not
+func canSeePrivateEvents(ctx context.Context, api eventsAPI, token, host, username string) (bool, string) {
+	login, scopes, err := tokenScopes(ctx, api, token, host)
+	if err != nil {
+		return false, fmt.Sprintf("showing public events only: failed to check token scopes: %v", err)
+	}
+	if !strings.EqualFold(login, username) {
+		return false, fmt.Sprintf("showing public events only: private events are only visible to %s's own token (token belongs to %s)", username, login)
+	}
+	if scopes != nil && !slices.Contains(scopes, "repo") {
+		return false, "showing public events only: token is missing the 'repo' scope needed for private events"
+	}
+	return true, ""
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTokenScopesCachedPerToken(t *testing.T) {
+	t.Cleanup(func() { tokenLogins.Delete("\x00token") })
+	ctx := context.Background()
+	if ok, warning := canSeePrivateEvents(ctx, newStubAPI(0), "token", "", "octocat"); !ok {
+		t.Fatalf("canSeePrivateEvents() = false (%s), want true", warning)
+	}
+
+	// The token's login is cached, so an API that can't look it up doesn't matter
+	broken := stubAPI{}
+	if ok, warning := canSeePrivateEvents(ctx, broken, "token", "", "octocat"); !ok {
+		t.Errorf("canSeePrivateEvents() looked up the token's scopes again: %s", warning)
+	}
+	for _, token := range []string{"other", ""} {
+		if ok, _ := canSeePrivateEvents(ctx, broken, token, "", "octocat"); ok {
+			t.Errorf("canSeePrivateEvents() reused the scopes of another token for %q", token)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ansi16 are the xterm colors for the 16 basic ANSI colors
+var ansi16 = []string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansi256 returns the hex color of an xterm 256-color palette index
+func ansi256(n int) string {
+	switch {
+	case n < 16:
+		return ansi16[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	default:
+		gray := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// sgrState is the text style set by ANSI SGR escape sequences
+type sgrState struct {
+	fg, bg                             string
+	bold, faint, italic, underline, rv bool
+}
+
+func (s sgrState) css() string {
+	fg, bg := s.fg, s.bg
+	if s.rv {
+		fg, bg = cmp.Or(bg, "#1e1e1e"), cmp.Or(fg, "#d4d4d4")
+	}
+	var css []string
+	if fg != "" {
+		css = append(css, "color:"+fg)
+	}
+	if bg != "" {
+		css = append(css, "background:"+bg)
+	}
+	if s.bold {
+		css = append(css, "font-weight:bold")
+	}
+	if s.faint {
+		css = append(css, "opacity:0.6")
+	}
+	if s.italic {
+		css = append(css, "font-style:italic")
+	}
+	if s.underline {
+		css = append(css, "text-decoration:underline")
+	}
+	return strings.Join(css, ";")
+}
+
+// extendedColor parses the 5;n and 2;r;g;b forms following 38 or 48, returning the codes consumed
+func extendedColor(codes []int) (string, int) {
+	if len(codes) >= 2 && codes[0] == 5 {
+		return ansi256(codes[1]), 2
+	}
+	if len(codes) >= 4 && codes[0] == 2 {
+		return fmt.Sprintf("#%02x%02x%02x", codes[1], codes[2], codes[3]), 4
+	}
+	return "", len(codes)
+}
+
+// apply updates the state with the codes of an SGR sequence
+func (s *sgrState) apply(params string) {
+	var codes []int
+	for _, p := range strings.Split(params, ";") {
+		n, _ := strconv.Atoi(p) // an empty parameter means 0
+		codes = append(codes, n)
+	}
+	for i := 0; i < len(codes); i++ {
+		switch c := codes[i]; {
+		case c == 0:
+			*s = sgrState{}
+		case c == 1:
+			s.bold = true
+		case c == 2:
+			s.faint = true
+		case c == 3:
+			s.italic = true
+		case c == 4:
+			s.underline = true
+		case c == 7:
+			s.rv = true
+		case c == 22:
+			s.bold, s.faint = false, false
+		case c == 23:
+			s.italic = false
+		case c == 24:
+			s.underline = false
+		case c == 27:
+			s.rv = false
+		case c >= 30 && c <= 37:
+			s.fg = ansi16[c-30]
+		case c >= 90 && c <= 97:
+			s.fg = ansi16[c-90+8]
+		case c == 39:
+			s.fg = ""
+		case c >= 40 && c <= 47:
+			s.bg = ansi16[c-40]
+		case c >= 100 && c <= 107:
+			s.bg = ansi16[c-100+8]
+		case c == 49:
+			s.bg = ""
+		case c == 38 || c == 48:
+			color, n := extendedColor(codes[i+1:])
+			if c == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+			i += n
+		}
+	}
+}
+
+// cutOSC splits the body of an OSC sequence from the text after its terminator (ST or BEL)
+func cutOSC(s string) (body, rest string, ok bool) {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\a':
+			return s[:i], s[i+1:], true
+		case s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\':
+			return s[:i], s[i+2:], true
+		}
+	}
+	return "", "", false
+}
+
+// ansiToHTML converts text with ANSI color escape sequences into an HTML page
+func ansiToHTML(text, title string) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" + html.EscapeString(title) + "</title>\n</head>\n")
+	sb.WriteString(`<body style="background:#1e1e1e;color:#d4d4d4;margin:0;padding:1em;">` + "\n")
+	sb.WriteString(`<pre style="font-family:Menlo,Consolas,'DejaVu Sans Mono',monospace;font-size:13px;line-height:1.2;">`)
+
+	var state sgrState
+	open, link := false, false
+	for len(text) > 0 {
+		i := strings.IndexByte(text, '\x1b')
+		if i < 0 {
+			sb.WriteString(html.EscapeString(text))
+			break
+		}
+		sb.WriteString(html.EscapeString(text[:i]))
+		text = text[i:]
+		if len(text) >= 2 && text[1] == ']' {
+			// OSC sequence; OSC 8 hyperlinks become anchors
+			body, rest, ok := cutOSC(text[2:])
+			if !ok {
+				break
+			}
+			text = rest
+			if params, ok := strings.CutPrefix(body, "8;"); ok {
+				_, uri, _ := strings.Cut(params, ";")
+				if link {
+					sb.WriteString("</a>")
+					link = false
+				}
+				if uri != "" {
+					sb.WriteString(`<a href="` + html.EscapeString(uri) + `" style="color:inherit">`)
+					link = true
+				}
+			}
+			continue
+		}
+		if len(text) < 2 || text[1] != '[' {
+			text = text[1:] // not a CSI sequence
+			continue
+		}
+		end := strings.IndexFunc(text[2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+		if end < 0 {
+			break
+		}
+		params, final := text[2:2+end], text[2+end]
+		text = text[3+end:]
+		if final != 'm' {
+			continue // cursor movement etc.
+		}
+		state.apply(params)
+		if open {
+			sb.WriteString("</span>")
+			open = false
+		}
+		if css := state.css(); css != "" {
+			sb.WriteString(`<span style="` + css + `">`)
+			open = true
+		}
+	}
+	if open {
+		sb.WriteString("</span>")
+	}
+	if link {
+		sb.WriteString("</a>")
+	}
+	sb.WriteString("</pre>\n</body>\n</html>\n")
+	return sb.String()
+}
+
+// saveScreenshot writes the rendered view to .ans (raw ANSI) and .html files, returning the base name
+func saveScreenshot(view, username string) (string, error) {
+	base := fmt.Sprintf("gitfamous-%s-%s", username, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(base+".ans", []byte(view), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(base+".html", []byte(ansiToHTML(view, "gitfamous "+username)), 0o644); err != nil {
+		return "", err
+	}
+	return base, nil
+}
+
+// screenshotStatus reports the outcome of saveScreenshot
+func screenshotStatus(base string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("failed to save screenshot: %v", err)
+	}
+	return fmt.Sprintf("saved screenshot to %s.ans and %s.html", base, base)
+}
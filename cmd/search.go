@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchMarker is shown in front of events matching the search
+var searchMarker = "🔍 "
+
+// openSearch shows the prompt for searching the fetched events
+func (m *model) openSearch() tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = "search: "
+	ti.Placeholder = "commit message, comment, branch, ..."
+	ti.SetValue(m.searchQuery)
+	ti.CursorEnd()
+	m.search = ti
+	m.showSearch = true
+	m.indexSearch()
+	return m.search.Focus()
+}
+
+// searchText returns everything an event can be found by: its row and every string in its payload
+func searchText(item eventItem) string {
+	var sb strings.Builder
+	sb.WriteString(item.Type + "\n" + item.Description + "\n")
+	if item.Repository != nil {
+		sb.WriteString(item.Repository.Name + "\n")
+	}
+	if item.Actor != nil {
+		sb.WriteString(item.Actor.Login + "\n")
+	}
+	if item.Event != nil {
+		var payload any
+		if err := json.Unmarshal(item.Event.GetRawPayload(), &payload); err == nil {
+			collectStrings(&sb, payload)
+		}
+	}
+	return strings.ToLower(sb.String())
+}
+
+// collectStrings writes every string value in a decoded JSON document to sb
+func collectStrings(sb *strings.Builder, v any) {
+	switch v := v.(type) {
+	case string:
+		sb.WriteString(v + "\n")
+	case []any:
+		for _, elem := range v {
+			collectStrings(sb, elem)
+		}
+	case map[string]any:
+		for _, elem := range v {
+			collectStrings(sb, elem)
+		}
+	}
+}
+
+// indexSearch builds the search text of the events (reset searchTexts when the events change)
+func (m *model) indexSearch() {
+	if m.searchTexts != nil && len(m.searchTexts) == len(m.events) {
+		return
+	}
+	m.searchTexts = make([]string, len(m.events))
+	for i, item := range m.events {
+		m.searchTexts[i] = searchText(item)
+	}
+}
+
+// updateSearchMatches finds the events matching the search query
+func (m *model) updateSearchMatches() {
+	m.searchHits = nil
+	query := strings.ToLower(strings.TrimSpace(m.searchQuery))
+	if query == "" {
+		return
+	}
+	m.indexSearch()
+	m.searchHits = make(map[int]bool)
+	for i, text := range m.searchTexts {
+		if strings.Contains(text, query) {
+			m.searchHits[i] = true
+		}
+	}
+}
+
+// searchRows returns the table rows of the search matches in order
+func (m model) searchRows() []int {
+	var rows []int
+	for row, idx := range m.visible {
+		if m.searchHits[idx] {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// jumpToMatch moves the cursor to the next (dir > 0) or previous (dir < 0) match, wrapping around;
+// dir 0 stays on the current row if it matches.
+func (m *model) jumpToMatch(dir int) {
+	if m.searchQuery == "" {
+		m.status = "no search (ctrl+f to search)"
+		return
+	}
+	rows := m.searchRows()
+	if len(rows) == 0 {
+		m.status = fmt.Sprintf("no matches for %q", m.searchQuery)
+		return
+	}
+	cursor := m.table.Cursor()
+	pos := -1
+	switch {
+	case dir > 0:
+		pos = slices.IndexFunc(rows, func(row int) bool { return row > cursor })
+	case dir < 0:
+		for i := len(rows) - 1; i >= 0; i-- {
+			if rows[i] < cursor {
+				pos = i
+				break
+			}
+		}
+		if pos < 0 {
+			pos = len(rows) - 1
+		}
+	default:
+		pos = slices.IndexFunc(rows, func(row int) bool { return row >= cursor })
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	m.table.SetCursor(rows[pos])
+	m.status = fmt.Sprintf("match %d/%d for %q • n/N next/prev • esc clear", pos+1, len(rows), m.searchQuery)
+}
+
+// clearSearch removes the search highlighting
+func (m *model) clearSearch() {
+	if m.searchQuery != "" {
+		m.pushView()
+	}
+	m.searchQuery = ""
+	m.searchHits = nil
+	m.setupTable()
+}
+
+func (m model) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.showSearch = false
+			return m, nil
+		case "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			m.showSearch = false
+			if query := strings.TrimSpace(m.search.Value()); query != m.searchQuery {
+				m.pushView()
+				m.searchQuery = query
+			}
+			m.updateSearchMatches()
+			m.setupTable()
+			if m.searchQuery != "" {
+				m.jumpToMatch(0)
+			}
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
+func (m model) searchView() string {
+	hint := "enter search • esc cancel"
+	if query := strings.ToLower(strings.TrimSpace(m.search.Value())); query != "" {
+		// Preview how many events match as you type
+		preview := m
+		preview.searchQuery = query
+		preview.updateSearchMatches()
+		hint = fmt.Sprintf("%d matches • %s", len(preview.searchHits), hint)
+	}
+	return paletteStyle.Render(m.search.View() + "\n" + paletteDimStyle.Render(hint))
+}
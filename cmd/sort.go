@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sortBy is the --sort order of events: date, repo, type or actor
+var sortBy string
+
+var sortModes = []string{"date", "repo", "type", "actor"}
+
+// checkSort validates a --sort value
+func checkSort(by string) error {
+	if by != "" && !slices.Contains(sortModes, by) {
+		return fmt.Errorf("invalid --sort %s (must be one of: %s)", by, strings.Join(sortModes, ", "))
+	}
+	return nil
+}
+
+// compareEvents orders events by the --sort key, then newest first with the
+// event ID as a tiebreak so events from the same second keep a stable order
+func compareEvents(a, b eventItem, by string) int {
+	var n int
+	switch by {
+	case "repo":
+		n = strings.Compare(a.Repository.Name, b.Repository.Name)
+	case "type":
+		n = strings.Compare(a.Type, b.Type)
+	case "actor":
+		n = strings.Compare(strings.ToLower(a.Actor.Login), strings.ToLower(b.Actor.Login))
+	}
+	if n != 0 {
+		return n
+	}
+	if n = b.CreatedAt.Compare(a.CreatedAt); n != 0 {
+		return n
+	}
+	// IDs are increasing decimal numbers
+	idA, idB := a.Event.GetID(), b.Event.GetID()
+	return cmp.Or(cmp.Compare(len(idB), len(idA)), strings.Compare(idB, idA))
+}
+
+// sortEvents sorts events in place by the --sort key
+func sortEvents(items []eventItem, by string) {
+	slices.SortStableFunc(items, func(a, b eventItem) int {
+		return compareEvents(a, b, by)
+	})
+}
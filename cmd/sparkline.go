@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// sparklines is --sparklines
+var sparklines bool
+
+// sparklineWidth is the number of time buckets in a repository's sparkline
+const sparklineWidth = 8
+
+// sparkBlocks are the levels of a sparkline, lowest first
+var sparkBlocks = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// asciiSparkBlocks are the levels of a sparkline on ASCII consoles
+var asciiSparkBlocks = []string{"_", "_", ".", ".", "-", "-", "^", "^"}
+
+// repoSparklines returns a sparkline of each repository's activity over the span of
+// the fetched events (oldest on the left), scaled across all repositories so the
+// busiest ones stand out
+func (m model) repoSparklines() map[string]string {
+	if len(m.events) == 0 {
+		return nil
+	}
+	oldest, newest := m.events[0].CreatedAt, m.events[0].CreatedAt
+	for _, item := range m.events {
+		if item.CreatedAt.Before(oldest) {
+			oldest = item.CreatedAt
+		}
+		if item.CreatedAt.After(newest) {
+			newest = item.CreatedAt
+		}
+	}
+	span := max(newest.Sub(oldest), time.Second)
+
+	counts := make(map[string][]int)
+	var peak int
+	for _, item := range m.events {
+		repo := item.Repository.Name
+		if counts[repo] == nil {
+			counts[repo] = make([]int, sparklineWidth)
+		}
+		i := min(int(item.CreatedAt.Sub(oldest)*sparklineWidth/span), sparklineWidth-1)
+		counts[repo][i]++
+		peak = max(peak, counts[repo][i])
+	}
+
+	blocks := sparkBlocks
+	if asciiConsole {
+		blocks = asciiSparkBlocks
+	}
+	lines := make(map[string]string, len(counts))
+	for repo, buckets := range counts {
+		var sb strings.Builder
+		for _, n := range buckets {
+			if n == 0 {
+				sb.WriteString(" ")
+				continue
+			}
+			sb.WriteString(blocks[min((n*len(blocks)-1)/peak, len(blocks)-1)])
+		}
+		lines[repo] = sb.String()
+	}
+	return lines
+}
+
+// column returns the index of the events table column titled title, or -1
+func (m model) column(title string) int {
+	return slices.IndexFunc(m.table.Columns(), func(c table.Column) bool { return c.Title == title })
+}
+
+// sparkline draws values as a line of blocks from their lowest to their highest
+func sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	blocks := sparkBlocks
+	if asciiConsole {
+		blocks = asciiSparkBlocks
+	}
+	low, high := slices.Min(values), slices.Max(values)
+	var sb strings.Builder
+	for _, v := range values {
+		i := 0
+		if high > low {
+			i = (v - low) * (len(blocks) - 1) / (high - low)
+		}
+		sb.WriteString(blocks[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// splitModel renders two users' feeds side by side
+type splitModel struct {
+	panes   [2]model
+	focused int
+	width   int
+}
+
+func initialSplitModel(left, right model) splitModel {
+	m := splitModel{panes: [2]model{left, right}}
+	for i := range m.panes {
+		m.panes[i].keys.SwitchPane.SetEnabled(true)
+	}
+	m.setFocus(0)
+	return m
+}
+
+func (m splitModel) Init() tea.Cmd {
+	return tea.Batch(m.panes[0].Init(), m.panes[1].Init())
+}
+
+func (m *splitModel) setFocus(pane int) {
+	m.focused = pane
+	for i := range m.panes {
+		m.panes[i].blurred = i != pane
+		if m.panes[i].blurred {
+			m.panes[i].table.Blur()
+		} else {
+			m.panes[i].table.Focus()
+		}
+		m.panes[i].applyTableStyles()
+	}
+}
+
+func (m splitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		var cmds []tea.Cmd
+		for i := range m.panes {
+			pane, cmd := m.panes[i].Update(tea.WindowSizeMsg{Width: msg.Width / 2, Height: msg.Height})
+			m.panes[i] = pane.(model)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+
+	// Results go to the pane that asked for them, which needn't be the focused one
+	case fetchEventsMsg:
+		return m.updatePane(msg.username, msg.received, msg)
+	case fetchRepoMsg:
+		return m.updatePane(msg.username, msg.received, msg)
+	case fetchCommitsMsg:
+		return m.updatePane(msg.username, msg.received, msg)
+	case confirmMsg:
+		return m.updatePane(msg.username, msg.received, msg)
+	case actionDoneMsg:
+		return m.updatePane(msg.username, msg.received, msg)
+
+	case notificationsMsg:
+		for i := range m.panes {
+			if m.panes[i].username == msg.username && m.panes[i].showNotifications {
+				updated, cmd := m.panes[i].Update(msg)
+				m.panes[i] = updated.(model)
+				return m, cmd
+			}
+		}
+		return m, nil
+
+	case loadMoreMsg:
+		return m.updatePane(msg.username, msg.received, msg)
+
+	case spinner.TickMsg:
+		// Keep the spinners of background panes going
+		var cmds []tea.Cmd
+		for i := range m.panes {
+			updated, cmd := m.panes[i].Update(msg)
+			m.panes[i] = updated.(model)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.MouseMsg:
+		pane := 0
+		if m.width > 0 && msg.X >= m.width/2 {
+			pane = 1
+			msg.X -= m.width / 2
+		}
+		msg.Y -= tabBarHeight // pane title
+		if pane != m.focused && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			m.setFocus(pane)
+		}
+		updated, cmd := m.panes[pane].Update(msg)
+		m.panes[pane] = updated.(model)
+		return m, cmd
+
+	case tea.KeyMsg:
+		current := m.panes[m.focused]
+		if !current.capturingInput() && key.Matches(msg, current.keys.SwitchPane) {
+			m.setFocus(1 - m.focused)
+			return m, nil
+		}
+		if !current.capturingInput() && current.err == nil && key.Matches(msg, current.keys.Screenshot) {
+			// Capture both panes
+			base, err := saveScreenshot(m.View(), m.panes[0].username+"-"+m.panes[1].username)
+			m.panes[m.focused].status = screenshotStatus(base, err)
+			return m, nil
+		}
+	}
+
+	pane, cmd := m.panes[m.focused].Update(msg)
+	m.panes[m.focused] = pane.(model)
+	return m, cmd
+}
+
+// updatePane passes msg to the pane of username's (received) events
+func (m splitModel) updatePane(username string, received bool, msg tea.Msg) (tea.Model, tea.Cmd) {
+	for i := range m.panes {
+		if m.panes[i].username != username || m.panes[i].fetch.Received != received {
+			continue
+		}
+		pane, cmd := m.panes[i].Update(msg)
+		m.panes[i] = pane.(model)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m splitModel) View() string {
+	title := func(i int) string {
+		if i == m.focused {
+			return activeTabStyle.Render(" " + m.panes[i].username + " ")
+		}
+		return inactiveTabStyle.Render(" " + m.panes[i].username + " ")
+	}
+	left := lipgloss.NewStyle().Width(m.width / 2).Render(title(0) + "\n" + m.panes[0].View())
+	right := lipgloss.NewStyle().Width(m.width - m.width/2).Render(title(1) + "\n" + m.panes[1].View())
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	"github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sshListen   string
+	sshHostKey  string
+	sshInsecure bool
+)
+
+// sshBrowserStatus is shown instead of opening a browser in sessions served over
+// ssh, since it would open on the server
+const sshBrowserStatus = "can't open a browser over ssh"
+
+// sshKeyUsers is an authorized key and the users shown to whoever connects with it
+type sshKeyUsers struct {
+	key   ssh.PublicKey
+	users []User
+}
+
+// sshApp builds a TUI for each SSH session
+type sshApp struct {
+	users []User // shown to keys without their own users
+	keys  []sshKeyUsers
+	fetch fetchOptions
+}
+
+// usersFor returns the users to show to a session's public key, or false if the key isn't allowed
+func (a sshApp) usersFor(key ssh.PublicKey) ([]User, bool) {
+	if len(a.keys) == 0 {
+		return a.users, true
+	}
+	for _, k := range a.keys {
+		if key != nil && ssh.KeysEqual(k.key, key) {
+			return k.users, true
+		}
+	}
+	return nil, false
+}
+
+func (a sshApp) handler(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
+	users, ok := a.usersFor(sess.PublicKey())
+	if !ok {
+		return nil, nil // already rejected by the auth handler
+	}
+	// Opening a browser would happen on the server so enter shows the repo in the TUI instead
+	// and the sessions can't act as the token's user or write to the server
+	view := viewOptions{TimeFormat: timeFormatRelative, EnterAction: enterActionRepo, ReadOnly: true}
+	var tabs []model
+	for _, user := range users {
+		tabs = append(tabs, initialModel(user, a.fetch, view))
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if len(tabs) == 1 {
+		return tabs[0], opts
+	}
+	return initialMultiUserModel(tabs, a.fetch, view), opts
+}
+
+// loadSSHKeys parses the config's authorized keys and resolves their users
+func loadSSHKeys(conf *Config, users []User) ([]sshKeyUsers, error) {
+	if conf.SSH == nil {
+		return nil, nil
+	}
+	var keys []sshKeyUsers
+	for i, k := range conf.SSH.Keys {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.Key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ssh key %d: %v", i+1, err)
+		}
+		ku := sshKeyUsers{key: key, users: users}
+		if len(k.Users) > 0 {
+			if ku.users, err = resolveUsers(conf, k.Users); err != nil {
+				return nil, fmt.Errorf("failed to resolve users for ssh key %d: %v", i+1, err)
+			}
+		}
+		keys = append(keys, ku)
+	}
+	return keys, nil
+}
+
+var sshCmd = &cobra.Command{
+	Use:   "ssh [username...]",
+	Short: "Serve the TUI over SSH",
+	Long: `Serve the events TUI over SSH (e.g. on a shared team server) so anyone can
+connect with 'ssh -p 2222 host' without a token of their own.
+
+Access is restricted to the authorized keys listed in the config's ssh section,
+each optionally with its own users to show (otherwise they see the users given on
+the command line or the config's users). Without keys the server only starts with
+--insecure, and then any key can connect.
+
+Sessions only browse: starring, following, bookmarks, pins, mutes, screenshots and
+opening a browser are disabled since they'd act as the token's user or on the
+server.`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conf, users, err := setupSubcommand(args)
+		if err != nil {
+			return err
+		}
+		if err := setTheme(conf.Theme); err != nil {
+			return err
+		}
+		if err := setLayout(conf.Layout); err != nil {
+			return err
+		}
+		setTableStyle(conf.TableStyle)
+		if len(filterTypes) == 0 {
+			filterTypes = conf.Filter
+		}
+		filter, err := parseFilterTypes(filterTypes)
+		if err != nil {
+			return err
+		}
+		if err := checkSort(sortBy); err != nil {
+			return err
+		}
+		keys, err := loadSSHKeys(conf, users)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			if !sshInsecure {
+				return fmt.Errorf("no ssh keys in the config: list the authorized keys in its ssh section (or pass --insecure to let anyone who can reach the server connect)")
+			}
+			logger.Warn("no ssh keys in the config, anyone who can reach the server can connect")
+		}
+		if sshHostKey == "" && conf.SSH != nil {
+			sshHostKey = conf.SSH.HostKey
+		}
+		if sshHostKey == "" {
+			sshHostKey = filepath.Join(filepath.Dir(configPath), "ssh_host_ed25519")
+		}
+
+		app := sshApp{
+			users: users,
+			keys:  keys,
+			fetch: fetchOptions{Count: eventCount, FilterTypes: filter, PerPage: 100, Sort: sortBy},
+		}
+		// The styles are package globals rendered for the server's stdout (which usually
+		// isn't a terminal), so force colors for the clients
+		lipgloss.SetColorProfile(termenv.ANSI256)
+		lipgloss.SetHasDarkBackground(true)
+
+		srv, err := wish.NewServer(
+			wish.WithAddress(sshListen),
+			wish.WithHostKeyPath(sshHostKey),
+			wish.WithPublicKeyAuth(func(_ ssh.Context, key ssh.PublicKey) bool {
+				_, ok := app.usersFor(key)
+				return ok
+			}),
+			wish.WithMiddleware(
+				bubbletea.MiddlewareWithColorProfile(app.handler, termenv.ANSI256),
+				activeterm.Middleware(),
+				logging.StructuredMiddlewareWithLogger(logger, log.InfoLevel),
+			),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create ssh server: %v", err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+				logger.Error("serving ssh", "error", err)
+				stop()
+			}
+		}()
+		host, port, _ := net.SplitHostPort(sshListen)
+		if host == "" {
+			host = "localhost"
+		}
+		logger.Info("serving the TUI over ssh", "addr", sshListen, "connect", fmt.Sprintf("ssh -p %s %s", port, host))
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sshCmd)
+	sshCmd.Flags().StringVar(&sshListen, "listen", "localhost:2222", "Address to serve SSH on (e.g. :2222 for every interface)")
+	sshCmd.Flags().BoolVar(&sshInsecure, "insecure", false, "Serve without authorized keys in the config, to anyone who can connect")
+	sshCmd.Flags().StringVar(&sshHostKey, "host-key", "", "SSH host key file, generated if missing (default ssh_host_ed25519 next to the config)")
+	sshCmd.Flags().IntVarP(&eventCount, "count", "c", 0, "Number of events to fetch")
+	sshCmd.Flags().StringSliceVarP(&filterTypes, "filter", "f", nil, "Comma-separated list of event types or aliases to display")
+	sshCmd.Flags().StringVar(&sortBy, "sort", "date", "Order events by date, repo, type or actor")
+	sshCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	sshCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	sshCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	sshCmd.RegisterFlagCompletionFunc("filter", completeFilter)
+	sshCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(sortModes, cobra.ShellCompDirectiveNoFileComp))
+	sshCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
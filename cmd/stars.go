@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-github/v66/github"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	starsWidth    int
+	starsHeight   int
+	starsMaxPages int
+)
+
+// stargazersPerPage is the most stargazers the API returns per page
+const stargazersPerPage = 100
+
+// starPoint is the number of stars a repository had at a time
+type starPoint struct {
+	At    time.Time
+	Count int
+}
+
+// starHistory fetches the stargazer timestamps of owner/repo as a cumulative count.
+// Repositories with more than maxPages pages of stargazers are sampled: pages are
+// picked evenly (always including the first and last) and each page tells the
+// count its stargazers starred at. It also returns the number of pages fetched
+// and how many there are.
+func starHistory(ctx context.Context, client *github.Client, owner, repo string, maxPages int) ([]starPoint, int, int, error) {
+	r, _, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to get repository %s/%s: %v", owner, repo, err)
+	}
+	total := r.GetStargazersCount()
+	pages := (total + stargazersPerPage - 1) / stargazersPerPage
+	sample := make([]int, 0, min(pages, maxPages))
+	if pages <= maxPages {
+		for page := 1; page <= pages; page++ {
+			sample = append(sample, page)
+		}
+	} else {
+		for i := range max(maxPages, 2) {
+			sample = append(sample, 1+i*(pages-1)/(max(maxPages, 2)-1))
+		}
+		sample = slices.Compact(sample)
+	}
+
+	var points []starPoint
+	for _, page := range sample {
+		stargazers, _, err := client.Activity.ListStargazers(ctx, owner, repo, &github.ListOptions{Page: page, PerPage: stargazersPerPage})
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to list stargazers of %s/%s: %v", owner, repo, err)
+		}
+		for i, s := range stargazers {
+			points = append(points, starPoint{At: s.GetStarredAt().Time, Count: (page-1)*stargazersPerPage + i + 1})
+		}
+	}
+	// The API stops listing stargazers after a while on huge repos, so end on the
+	// repository's own count
+	if len(points) == 0 || points[len(points)-1].Count < total {
+		points = append(points, starPoint{At: time.Now(), Count: total})
+	}
+	return points, len(sample), pages, nil
+}
+
+// starsAt interpolates the number of stars at t between the known points
+func starsAt(points []starPoint, t time.Time) float64 {
+	i, _ := slices.BinarySearchFunc(points, t, func(p starPoint, t time.Time) int { return p.At.Compare(t) })
+	switch {
+	case i == len(points):
+		return float64(points[len(points)-1].Count)
+	case i == 0:
+		return 0
+	}
+	prev, next := points[i-1], points[i]
+	span := next.At.Sub(prev.At)
+	if span <= 0 {
+		return float64(next.Count)
+	}
+	frac := float64(t.Sub(prev.At)) / float64(span)
+	return float64(prev.Count) + frac*float64(next.Count-prev.Count)
+}
+
+// writeStarChart draws the cumulative stars over time as a width by height chart of
+// block characters with a star count axis on the left and dates below
+func writeStarChart(w io.Writer, points []starPoint, width, height int, now time.Time) {
+	first := points[0].At
+	span := max(now.Sub(first), time.Hour)
+	peak := points[len(points)-1].Count
+	label := humanize.Comma(int64(peak))
+	gutter := len(label) + 1
+	width = max(width-gutter-1, 10)
+
+	// Each column is filled to its count in eighths of a row
+	levels := make([]int, width)
+	for x := range levels {
+		at := first.Add(span * time.Duration(x+1) / time.Duration(width))
+		levels[x] = int(starsAt(points, at) * float64(height*8) / float64(max(peak, 1)))
+	}
+	for row := range height {
+		var sb strings.Builder
+		switch row {
+		case 0:
+			fmt.Fprintf(&sb, "%*s ", gutter-1, label)
+		case height - 1:
+			fmt.Fprintf(&sb, "%*s ", gutter-1, "0")
+		default:
+			sb.WriteString(strings.Repeat(" ", gutter))
+		}
+		sb.WriteString(chartAxis())
+		floor := (height - 1 - row) * 8
+		for _, level := range levels {
+			fill := min(max(level-floor, 0), 8)
+			switch {
+			case fill == 0:
+				sb.WriteString(" ")
+			case asciiConsole && fill == 8:
+				sb.WriteString("#")
+			case asciiConsole:
+				sb.WriteString(asciiSparkBlocks[fill-1])
+			default:
+				sb.WriteString(sparkBlocks[fill-1])
+			}
+		}
+		fmt.Fprintln(w, strings.TrimRight(sb.String(), " "))
+	}
+	start, end := first.Local().Format(time.DateOnly), now.Local().Format(time.DateOnly)
+	fmt.Fprintf(w, "%s%s%*s\n", strings.Repeat(" ", gutter+1), start, max(width-len(start), len(end)+1), end)
+}
+
+// chartAxis is the left edge of a chart
+func chartAxis() string {
+	if asciiConsole {
+		return "|"
+	}
+	return "│"
+}
+
+var starsCmd = &cobra.Command{
+	Use:   "stars <owner>/<repo>",
+	Short: "Chart a repository's stars over time",
+	Long: `Draw the cumulative stars of a repository over time in the terminal from the
+times its stargazers starred it.
+
+Repositories with more than --max-pages pages of 100 stargazers are sampled to
+save API requests, so their chart is interpolated between the sampled pages.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, repo, ok := strings.Cut(args[0], "/")
+		if !ok || owner == "" || repo == "" {
+			return fmt.Errorf("invalid repository %q: expected owner/repo", args[0])
+		}
+		conf, err := setupConfig()
+		if err != nil {
+			return err
+		}
+		setupConsole()
+		acct, err := conf.account(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account: %v", err)
+		}
+		client, err := newClient(cmp.Or(acct.Token, githubToken), acct.Host)
+		if err != nil {
+			return err
+		}
+		points, fetched, pages, err := starHistory(context.Background(), client, owner, repo, starsMaxPages)
+		if err != nil {
+			return err
+		}
+		total := points[len(points)-1].Count
+		if total == 0 {
+			fmt.Printf("%s/%s has no stars, yet\n", owner, repo)
+			return nil
+		}
+
+		width := starsWidth
+		if width == 0 {
+			width = 80
+			if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+				width = w
+			}
+		}
+		fmt.Printf("%s/%s: %s stars since %s", owner, repo, humanize.Comma(int64(total)), humanTime(points[0].At))
+		if fetched < pages {
+			fmt.Printf(" (sampled %d of %d pages of stargazers)", fetched, pages)
+		}
+		fmt.Print("\n\n")
+		writeStarChart(os.Stdout, points, width, max(starsHeight, 2), time.Now())
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(starsCmd)
+	starsCmd.Flags().IntVar(&starsWidth, "width", 0, "Width of the chart (default terminal width)")
+	starsCmd.Flags().IntVar(&starsHeight, "height", 12, "Height of the chart in rows")
+	starsCmd.Flags().IntVar(&starsMaxPages, "max-pages", 30, "Most pages of 100 stargazers to fetch; bigger repos are sampled")
+	starsCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	starsCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	starsCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	starsCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
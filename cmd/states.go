@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/go-github/v66/github"
+	"github.com/mattn/go-runewidth"
+)
+
+// Pull request state icons
+const (
+	prIcon       = ""
+	prMergedIcon = ""
+	prClosedIcon = ""
+	prDraftIcon  = ""
+)
+
+// Review state icons
+const (
+	reviewIcon         = ""
+	reviewApprovedIcon = ""
+	reviewChangesIcon  = ""
+	reviewCommentIcon  = ""
+)
+
+// sponsorshipIcon is the icon of SponsorshipEvent
+const sponsorshipIcon = "󰋑"
+
+// iconColors are the colors of the state icons in the events table
+var iconColors = map[string]lipgloss.Color{
+	prMergedIcon: "135",
+	prClosedIcon: "160",
+	prDraftIcon:  "244",
+
+	reviewApprovedIcon: "70",
+	reviewChangesIcon:  "160",
+
+	sponsorshipIcon: "205",
+}
+
+// prState returns the icon and wording of a PullRequestEvent, telling merged
+// from closed without merging and draft from ready PRs
+func prState(p *github.PullRequestEvent) (icon, action string) {
+	pr := p.GetPullRequest()
+	switch action = p.GetAction(); {
+	case action == "closed" && pr.GetMerged():
+		return prMergedIcon, "merged"
+	case action == "closed":
+		return prClosedIcon, "closed without merging"
+	case (action == "opened" || action == "reopened") && pr.GetDraft():
+		return prDraftIcon, action + " as draft"
+	}
+	return prIcon, action
+}
+
+// reviewState returns the icon and wording of a PullRequestReviewEvent's review state
+func reviewState(p *github.PullRequestReviewEvent) (icon, state string) {
+	switch state = strings.ToLower(p.GetReview().GetState()); state {
+	case "approved":
+		return reviewApprovedIcon, "approved"
+	case "changes_requested":
+		return reviewChangesIcon, "changes requested"
+	case "commented":
+		return reviewCommentIcon, "commented"
+	case "":
+		return reviewIcon, "reviewed"
+	}
+	return reviewIcon, state
+}
+
+// colorIcons renders the state icons in s in their colors
+func colorIcons(s string) string {
+	for icon, color := range iconColors {
+		if strings.Contains(s, icon) {
+			s = strings.ReplaceAll(s, icon, lipgloss.NewStyle().Foreground(color).Render(icon))
+		}
+	}
+	return s
+}
+
+// colorRows colors the state icons in the rendered table. Like linkRows this is
+// done after rendering since the table truncates cells by width, escape codes and all.
+// The selected row is left alone so it keeps the selection colors.
+func (m model) colorRows(view string) string {
+	if asciiConsole {
+		return view
+	}
+	var selected string
+	if row, col := m.table.SelectedRow(), m.column("Description"); col >= 0 && len(row) > col {
+		selected = runewidth.Truncate(row[col], m.table.Columns()[col].Width, "…")
+	}
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		if selected != "" && strings.Contains(ansi.Strip(line), selected) {
+			continue
+		}
+		lines[i] = colorIcons(line)
+	}
+	return strings.Join(lines, "\n")
+}
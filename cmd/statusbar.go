@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusBarView renders the footer with the user, filters, event count, data freshness and API rate limit
+func (m model) statusBarView() string {
+	barStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "236", Dark: "252"}).
+		Background(lipgloss.AdaptiveColor{Light: "254", Dark: "236"})
+	userStyle := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.SelectedFg).Background(currentTheme.SelectedBg).Padding(0, 1)
+	rateStyle := barStyle
+	if m.rate.Limit > 0 && m.rate.Remaining*10 < m.rate.Limit {
+		// Less than 10% of the rate limit left
+		rateStyle = rateStyle.Foreground(lipgloss.Color("204"))
+	}
+
+	filter := "all events"
+	if len(m.fetch.FilterTypes) > 0 {
+		filter = "filter: " + strings.Join(m.fetch.FilterTypes, ",")
+	}
+	if m.fetch.Expr != nil {
+		filter += " + expr"
+	}
+	segments := []string{
+		barStyle.Render(filter),
+		barStyle.Render(fmt.Sprintf("%d events", len(m.visibleEvents()))),
+		barStyle.Render(m.pageIndicator()),
+	}
+	if m.typeFilter != "" {
+		segments = append(segments, barStyle.Render("only "+strings.TrimSuffix(m.typeFilter, "Event")))
+	}
+	if item, ok := m.selectedEvent(); ok && m.typeColumn && !asciiConsole {
+		// The selected row's Type icon
+		segments = append(segments, barStyle.Render(typeTooltip(item)))
+	}
+	if m.searchQuery != "" {
+		segments = append(segments, barStyle.Render(fmt.Sprintf("%d matches for %q", len(m.searchRows()), m.searchQuery)))
+	}
+	if n := m.unreadCount(); n > 0 {
+		segments = append(segments, barStyle.Bold(true).Render(fmt.Sprintf("%d new", n)))
+	}
+	if m.notifications != "" {
+		style := barStyle
+		if m.notifications != "0" {
+			style = style.Bold(true)
+		}
+		segments = append(segments, style.Render(m.notifications+" notifications"))
+	}
+	if m.streak.Days > 0 {
+		segments = append(segments, barStyle.Render(m.streak.String()))
+	}
+	switch {
+	case m.loading:
+		segments = append(segments, barStyle.Render(m.spinner.View()+barStyle.Render(" refreshing (esc to cancel)")))
+	case m.loadingMore:
+		segments = append(segments, barStyle.Render(m.spinner.View()+barStyle.Render(" loading more")))
+	case !m.cachedAt.IsZero():
+		segments = append(segments, barStyle.Render("cached "+humanTime(m.cachedAt)))
+	case !m.fetchedAt.IsZero():
+		segments = append(segments, barStyle.Render("updated "+humanTime(m.fetchedAt)))
+	}
+	if m.rate.Limit > 0 {
+		segments = append(segments, rateStyle.Render(fmt.Sprintf("API %d/%d", m.rate.Remaining, m.rate.Limit)))
+	}
+
+	sep := " │ "
+	if asciiConsole {
+		sep = " | "
+	}
+	left := userStyle.Render(m.username) + barStyle.Render(" ") + strings.Join(segments, barStyle.Render(sep))
+	right := barStyle.Render(" gitfamous " + version() + " ")
+	gap := m.termWidth() - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 1 {
+		// Drop the version before truncating anything else
+		right = ""
+		gap = max(m.termWidth()-lipgloss.Width(left), 0)
+	}
+	bar := left + barStyle.Render(strings.Repeat(" ", gap)) + right
+	return lipgloss.NewStyle().MaxWidth(m.termWidth()).Render(bar)
+}
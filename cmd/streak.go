@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/spf13/cobra"
+)
+
+var streakFailIfBroken bool
+
+// contributionTypes are the event types that count towards a streak (roughly what
+// GitHub counts as contributions, so no stars or forks)
+var contributionTypes = map[string]bool{
+	"PushEvent":                     true,
+	"PullRequestEvent":              true,
+	"PullRequestReviewEvent":        true,
+	"PullRequestReviewCommentEvent": true,
+	"IssuesEvent":                   true,
+	"IssueCommentEvent":             true,
+	"CommitCommentEvent":            true,
+	"CreateEvent":                   true,
+	"ReleaseEvent":                  true,
+}
+
+// streakLayout is how the days of the streak archive are stored
+const streakLayout = "2006-01-02"
+
+// streakArchive is the on-disk record of the days a user contributed on. It only
+// ever grows, so streaks can outlast the 90 days the events API goes back.
+type streakArchive struct {
+	Days []string `json:"days"`
+}
+
+// streak is a user's current run of consecutive days with contributions
+type streak struct {
+	Days  int
+	Today bool // contributed today, otherwise the streak ends at midnight
+}
+
+func streakPath(username string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "streaks", username+".json"), nil
+}
+
+func loadStreakArchive(username string) (map[string]bool, error) {
+	fname, err := streakPath(username)
+	if err != nil {
+		return nil, err
+	}
+	days := make(map[string]bool)
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return days, nil
+		}
+		return nil, err
+	}
+	var archive streakArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("failed to parse streak archive for user %s: %v", username, err)
+	}
+	for _, day := range archive.Days {
+		days[day] = true
+	}
+	return days, nil
+}
+
+// updateStreak adds the days of the user's contributions in events to their streak
+// archive and returns their current streak
+func updateStreak(username string, events []*github.Event) (streak, error) {
+	days, err := loadStreakArchive(username)
+	if err != nil {
+		return streak{}, err
+	}
+	added := false
+	for _, event := range events {
+		if !contributionTypes[event.GetType()] || !strings.EqualFold(event.GetActor().GetLogin(), username) {
+			continue
+		}
+		day := event.GetCreatedAt().Local().Format(streakLayout)
+		if !days[day] {
+			days[day] = true
+			added = true
+		}
+	}
+	if added {
+		fname, err := streakPath(username)
+		if err != nil {
+			return streak{}, err
+		}
+		if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+			return streak{}, err
+		}
+		data, err := json.Marshal(streakArchive{Days: slices.Sorted(maps.Keys(days))})
+		if err != nil {
+			return streak{}, err
+		}
+		if err := os.WriteFile(fname, data, 0o600); err != nil {
+			return streak{}, err
+		}
+	}
+	return currentStreak(days, time.Now()), nil
+}
+
+// loadStreak returns the user's current streak from their archive alone
+func loadStreak(username string) (streak, error) {
+	days, err := loadStreakArchive(username)
+	if err != nil {
+		return streak{}, err
+	}
+	return currentStreak(days, time.Now()), nil
+}
+
+// currentStreak counts the consecutive days with contributions up to today, or up
+// to yesterday while there are none today yet
+func currentStreak(days map[string]bool, now time.Time) streak {
+	day := now.Local()
+	s := streak{Today: days[day.Format(streakLayout)]}
+	if !s.Today {
+		day = day.AddDate(0, 0, -1)
+	}
+	for days[day.Format(streakLayout)] {
+		s.Days++
+		day = day.AddDate(0, 0, -1)
+	}
+	return s
+}
+
+// String describes the streak, e.g. "12 day streak" or "12 day streak (not yet today)"
+func (s streak) String() string {
+	switch {
+	case s.Days == 0:
+		return "no streak"
+	case !s.Today:
+		return fmt.Sprintf("%d day streak (not yet today)", s.Days)
+	}
+	return fmt.Sprintf("%d day streak", s.Days)
+}
+
+var streakCmd = &cobra.Command{
+	Use:   "streak <username>",
+	Short: "Show a user's daily contribution streak",
+	Long: `Show how many days in a row a user has contributed (pushed, opened or
+reviewed PRs, filed or commented on issues, ...), counting today once they have.
+
+The days are archived as events are fetched, so a streak can go back further
+than the 90 days the events API covers as long as gitfamous runs regularly.
+
+With --fail-if-broken the exit status is 1 when there are no contributions
+today yet, so it can gate a daily reminder:
+
+  0 20 * * * gitfamous streak blacktop --fail-if-broken || notify-send "Keep your streak alive"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, users, err := setupSubcommand(args)
+		if err != nil {
+			return err
+		}
+		client, err := newClient(users[0].Token, users[0].Host)
+		if err != nil {
+			return err
+		}
+		username := users[0].Name
+		feed, err := fetchEvents(context.Background(), githubAPI{client}, username, fetchOptions{PerPage: 100, Host: users[0].Host})
+		if err != nil && !strings.HasPrefix(err.Error(), "no events found") {
+			return err
+		}
+		s := feed.streakOr(username)
+		fmt.Printf("%s: %s\n", username, s)
+		if streakFailIfBroken && !s.Today {
+			return fmt.Errorf("streak broken: %s has no contributions today", username)
+		}
+		return nil
+	},
+}
+
+// streakOr returns the streak of the feed, or the archived one when there isn't one
+// (e.g. no events were found)
+func (f *eventFeed) streakOr(username string) streak {
+	if f != nil && f.Streak.Days > 0 {
+		return f.Streak
+	}
+	s, err := loadStreak(username)
+	if err != nil {
+		logger.Debug("failed to load streak", "user", username, "error", err)
+	}
+	return s
+}
+
+func init() {
+	rootCmd.AddCommand(streakCmd)
+	streakCmd.Flags().BoolVar(&streakFailIfBroken, "fail-if-broken", false, "Exit with status 1 when there are no contributions today")
+	streakCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	streakCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	streakCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	streakCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxUserChoices is the number of users listed under the switch user prompt
+const maxUserChoices = 8
+
+// openUserPrompt shows the prompt for switching to another user
+func (m *model) openUserPrompt() tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = "user: "
+	ti.Placeholder = m.username
+	m.userPrompt = ti
+	m.userChoices = userHistory(m.username)
+	m.userCursor = -1
+	m.showUserPrompt = true
+	return m.userPrompt.Focus()
+}
+
+// userHistory returns the users from the config file followed by the recently viewed ones
+func userHistory(current string) []string {
+	var candidates []string
+	// Don't resolve tokens (no token_cmd) just to list names
+	if conf, err := readConfig(configPath); err == nil {
+		for _, user := range conf.Users {
+			candidates = append(candidates, user.Name)
+		}
+	}
+	candidates = append(candidates, recentUsers()...)
+
+	var names []string
+	for _, name := range candidates {
+		if name != current && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// filteredUserChoices returns the choices matching what's been typed so far
+func (m model) filteredUserChoices() []string {
+	query := strings.ToLower(strings.TrimSpace(m.userPrompt.Value()))
+	var choices []string
+	for _, name := range m.userChoices {
+		if strings.Contains(strings.ToLower(name), query) {
+			choices = append(choices, name)
+		}
+	}
+	return choices[:min(len(choices), maxUserChoices)]
+}
+
+func (m model) updateUserPrompt(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		choices := m.filteredUserChoices()
+		switch msg.String() {
+		case "esc":
+			m.showUserPrompt = false
+			return m, nil
+		case "ctrl+c":
+			return m, tea.Quit
+		case "up", "ctrl+p":
+			if len(choices) > 0 {
+				m.userCursor = max(m.userCursor-1, -1)
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if len(choices) > 0 {
+				m.userCursor = min(m.userCursor+1, len(choices)-1)
+			}
+			return m, nil
+		case "tab":
+			// Complete the selected (or first) choice
+			if len(choices) > 0 {
+				m.userPrompt.SetValue(choices[max(m.userCursor, 0)])
+				m.userPrompt.CursorEnd()
+				m.userCursor = -1
+			}
+			return m, nil
+		case "enter":
+			m.showUserPrompt = false
+			name := strings.TrimSpace(m.userPrompt.Value())
+			if m.userCursor >= 0 && m.userCursor < len(choices) {
+				name = choices[m.userCursor]
+			} else if name == "" && len(choices) > 0 {
+				name = choices[0]
+			}
+			if name == "" || name == m.username && m.err == nil {
+				return m, nil
+			}
+			return m, m.switchUser(name)
+		}
+	}
+	var cmd tea.Cmd
+	m.userPrompt, cmd = m.userPrompt.Update(msg)
+	// Typing resets the selection to the typed name
+	m.userCursor = -1
+	return m, cmd
+}
+
+// switchUser replaces the viewed user and fetches their events
+func (m *model) switchUser(name string) tea.Cmd {
+	m.username = name
+	m.err = nil
+	m.events = nil
+	m.visible = nil
+	m.cachedAt = time.Time{}
+	m.etag = ""
+	m.seenLoaded = false
+	m.status = ""
+	m.searchQuery = ""
+	return m.refetch()
+}
+
+func (m model) userPromptView() string {
+	var sb strings.Builder
+	sb.WriteString(m.userPrompt.View())
+	for i, name := range m.filteredUserChoices() {
+		sb.WriteString("\n")
+		if i == m.userCursor {
+			sb.WriteString(paletteSelectedStyle.Render("> " + name))
+		} else {
+			sb.WriteString(paletteDimStyle.Render("  " + name))
+		}
+	}
+	sb.WriteString("\n" + paletteDimStyle.Render("↑/↓ select • tab complete • enter switch • esc cancel"))
+	return paletteStyle.Render(sb.String())
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableStyle is the table_style section of the config
+type tableStyle struct {
+	Zebra    *bool    `yaml:"zebra,omitempty"`    // stripe every other row (default true)
+	Selected rowStyle `yaml:"selected,omitempty"` // the selected row (default the theme's colors)
+}
+
+// rowStyle overrides the style of a table row
+type rowStyle struct {
+	Foreground string `yaml:"foreground,omitempty"`
+	Background string `yaml:"background,omitempty"`
+	Bold       bool   `yaml:"bold,omitempty"`
+	Underline  bool   `yaml:"underline,omitempty"`
+}
+
+// currentTableStyle is the active table style (see setTableStyle)
+var currentTableStyle tableStyle
+
+// setTableStyle makes the table style from the config the active one
+func setTableStyle(s tableStyle) {
+	currentTableStyle = s
+}
+
+// zebra reports whether every other row of the events table is striped
+func zebra() bool {
+	return currentTheme.Stripe != nil && (currentTableStyle.Zebra == nil || *currentTableStyle.Zebra)
+}
+
+// applyTableStyles styles the events table from the theme, dimming the header and
+// selected row of the unfocused pane in split mode
+func (m *model) applyTableStyles() {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(tableBorder()).
+		BorderForeground(currentTheme.Border).
+		BorderBottom(true).
+		Foreground(currentTheme.Accent).
+		Bold(true)
+	s.Selected = s.Selected.
+		Foreground(currentTheme.SelectedFg).
+		Background(currentTheme.SelectedBg).
+		Bold(false)
+	if sel := currentTableStyle.Selected; sel != (rowStyle{}) {
+		if sel.Foreground != "" {
+			s.Selected = s.Selected.Foreground(lipgloss.Color(sel.Foreground))
+		}
+		if sel.Background != "" {
+			s.Selected = s.Selected.Background(lipgloss.Color(sel.Background))
+		}
+		s.Selected = s.Selected.Bold(sel.Bold).Underline(sel.Underline)
+	}
+	if m.blurred {
+		s.Header = s.Header.Foreground(currentTheme.Dim)
+		s.Selected = lipgloss.NewStyle().Background(currentTheme.BlurredBg)
+	}
+	m.table.SetStyles(s)
+	m.tableStyles = s
+}
+
+// stripeRows shades the background of every other row of the rendered table (after
+// the icons are colored, so the background is restored after each of them)
+func (m model) stripeRows(view string) string {
+	if !zebra() || len(m.table.Rows()) < 2 {
+		return view
+	}
+	// The escape sequence of the stripe background, if the terminal has colors
+	open, _, _ := strings.Cut(lipgloss.NewStyle().Background(currentTheme.Stripe).Render(" "), " ")
+	if open == "" {
+		return view
+	}
+	const reset = "\x1b[0m"
+	headerHeight := tableRowsOffset - 1 // no outer border on the bare table
+	top, cursor := m.tableTopRow(), m.table.Cursor()
+	lines := strings.Split(view, "\n")
+	for i := headerHeight; i < len(lines); i++ {
+		row := top + i - headerHeight
+		if row%2 == 0 || row == cursor {
+			continue
+		}
+		lines[i] = open + strings.ReplaceAll(lines[i], reset, reset+open) + reset
+	}
+	return strings.Join(lines, "\n")
+}
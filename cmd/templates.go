@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// descriptionTemplates override the description of an event type (see Config.Templates)
+var descriptionTemplates map[string]*template.Template
+
+var templateFuncs = template.FuncMap{
+	"trimRef": func(ref string) string {
+		return strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/tags/")
+	},
+	"firstLine": func(s string) string {
+		line, _, _ := strings.Cut(s, "\n")
+		return line
+	},
+	"truncate": func(n int, s string) string {
+		if r := []rune(s); len(r) > n {
+			return string(r[:max(n-1, 0)]) + "…"
+		}
+		return s
+	},
+}
+
+// compileTemplates parses the description templates from the config, keyed by event type
+func compileTemplates(templates map[string]string) error {
+	compiled := make(map[string]*template.Template, len(templates))
+	for eventType, text := range templates {
+		tmpl, err := template.New(eventType).Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid template for %s: %v", eventType, err)
+		}
+		compiled[eventType] = tmpl
+	}
+	descriptionTemplates = compiled
+	return nil
+}
+
+// templateDescription renders the event's description with its configured template
+func templateDescription(event *github.Event) (string, bool) {
+	tmpl, ok := descriptionTemplates[event.GetType()]
+	if !ok {
+		return "", false
+	}
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return "", false
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, payload); err != nil {
+		logger.Debug("rendering description template", "type", event.GetType(), "error", err)
+		return "", false
+	}
+	// Keep the table on one line per event
+	return strings.Join(strings.Fields(sb.String()), " "), true
+}
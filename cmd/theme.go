@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the color palette used by the TUI (the colors of the auto theme adapt
+// to the terminal background)
+type Theme struct {
+	Accent        lipgloss.TerminalColor // headers, titles and indicators
+	SelectedFg    lipgloss.TerminalColor
+	SelectedBg    lipgloss.TerminalColor
+	Border        lipgloss.TerminalColor
+	BorderBlurred lipgloss.TerminalColor
+	BlurredBg     lipgloss.TerminalColor // selected row of the unfocused pane in split mode
+	Stripe        lipgloss.TerminalColor // background of every other row of the events table
+	Dim           lipgloss.TerminalColor
+	// Glamour is the glamour style used to render markdown in the pager ("" for dark
+	// or light by the terminal background)
+	Glamour string
+}
+
+const defaultThemeName = "auto"
+
+var themes = map[string]Theme{
+	defaultThemeName: {
+		Accent:        lipgloss.AdaptiveColor{Light: "27", Dark: "63"},
+		SelectedFg:    lipgloss.AdaptiveColor{Light: "231", Dark: "229"},
+		SelectedBg:    lipgloss.AdaptiveColor{Light: "33", Dark: "57"},
+		Border:        lipgloss.AdaptiveColor{Light: "250", Dark: "240"},
+		BorderBlurred: lipgloss.AdaptiveColor{Light: "254", Dark: "236"},
+		BlurredBg:     lipgloss.AdaptiveColor{Light: "252", Dark: "238"},
+		Stripe:        lipgloss.AdaptiveColor{Light: "255", Dark: "235"},
+		Dim:           lipgloss.AdaptiveColor{Light: "244", Dark: "241"},
+	},
+	"dark": {
+		Accent:        lipgloss.Color("63"),
+		SelectedFg:    lipgloss.Color("229"),
+		SelectedBg:    lipgloss.Color("57"),
+		Border:        lipgloss.Color("240"),
+		BorderBlurred: lipgloss.Color("236"),
+		BlurredBg:     lipgloss.Color("238"),
+		Stripe:        lipgloss.Color("235"),
+		Dim:           lipgloss.Color("241"),
+		Glamour:       "dark",
+	},
+	"light": {
+		Accent:        lipgloss.Color("27"),
+		SelectedFg:    lipgloss.Color("231"),
+		SelectedBg:    lipgloss.Color("33"),
+		Border:        lipgloss.Color("250"),
+		BorderBlurred: lipgloss.Color("254"),
+		BlurredBg:     lipgloss.Color("252"),
+		Stripe:        lipgloss.Color("255"),
+		Dim:           lipgloss.Color("244"),
+		Glamour:       "light",
+	},
+	"dracula": {
+		Accent:        lipgloss.Color("#bd93f9"),
+		SelectedFg:    lipgloss.Color("#f8f8f2"),
+		SelectedBg:    lipgloss.Color("#6272a4"),
+		Border:        lipgloss.Color("#44475a"),
+		BorderBlurred: lipgloss.Color("#282a36"),
+		BlurredBg:     lipgloss.Color("#44475a"),
+		Stripe:        lipgloss.Color("#343746"),
+		Dim:           lipgloss.Color("#6272a4"),
+		Glamour:       "dracula",
+	},
+	"nord": {
+		Accent:        lipgloss.Color("#88c0d0"),
+		SelectedFg:    lipgloss.Color("#eceff4"),
+		SelectedBg:    lipgloss.Color("#5e81ac"),
+		Border:        lipgloss.Color("#4c566a"),
+		BorderBlurred: lipgloss.Color("#3b4252"),
+		BlurredBg:     lipgloss.Color("#434c5e"),
+		Stripe:        lipgloss.Color("#3b4252"),
+		Dim:           lipgloss.Color("#616e88"),
+		Glamour:       "dark",
+	},
+	"gruvbox": {
+		Accent:        lipgloss.Color("#fabd2f"),
+		SelectedFg:    lipgloss.Color("#282828"),
+		SelectedBg:    lipgloss.Color("#d79921"),
+		Border:        lipgloss.Color("#504945"),
+		BorderBlurred: lipgloss.Color("#3c3836"),
+		BlurredBg:     lipgloss.Color("#504945"),
+		Stripe:        lipgloss.Color("#32302f"),
+		Dim:           lipgloss.Color("#928374"),
+		Glamour:       "dark",
+	},
+}
+
+// themeFlag is --theme
+var themeFlag string
+
+// currentTheme is the active theme (see setTheme)
+var currentTheme = themes[defaultThemeName]
+
+// themeNames returns the available theme names (auto first)
+func themeNames() []string {
+	var names []string
+	for name := range themes {
+		if name != defaultThemeName {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return append([]string{defaultThemeName}, names...)
+}
+
+// setTheme switches the active theme and rebuilds the package styles from it
+func setTheme(name string) error {
+	if name == "" || name == "default" { // the auto theme used to be called default
+		name = defaultThemeName
+	}
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %s (must be one of: %s)", name, strings.Join(themeNames(), ", "))
+	}
+	if name == defaultThemeName {
+		detectBackground()
+	}
+	currentTheme = t
+
+	baseTableStyle = baseTableStyle.BorderForeground(t.Border)
+	activeTabStyle = activeTabStyle.Foreground(t.SelectedFg).Background(t.SelectedBg)
+	tabIndicatorStyle = tabIndicatorStyle.Foreground(t.Accent)
+	paletteStyle = paletteStyle.BorderForeground(t.Accent)
+	paletteSelectedStyle = paletteSelectedStyle.Foreground(t.SelectedFg).Background(t.SelectedBg)
+	paletteDimStyle = paletteDimStyle.Foreground(t.Dim)
+	repoTitleStyle = repoTitleStyle.Foreground(t.Accent)
+	repoLabelStyle = repoLabelStyle.Foreground(t.Dim)
+	pagerFooterStyle = pagerFooterStyle.Foreground(t.Dim)
+	return nil
+}
+
+// detectBackground asks the terminal whether its background is dark for the
+// adaptive colors. The answer is cached, so it has to be asked before a program
+// takes over the terminal.
+func detectBackground() {
+	logger.Debug("detected terminal background", "dark", lipgloss.HasDarkBackground())
+}
+
+// glamourStyle is the glamour style of the active theme
+func glamourStyle() string {
+	switch {
+	case currentTheme.Glamour != "":
+		return currentTheme.Glamour
+	case lipgloss.HasDarkBackground():
+		return "dark"
+	}
+	return "light"
+}
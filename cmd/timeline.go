@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// timelineHeight is the height of the timeline's bars in lines
+const timelineHeight = 10
+
+// barBlocks are the partial blocks of a bar, in eighths of a line
+var barBlocks = []string{"", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// openTimeline shows the timeline of the events in the table, per hour when
+// they span less than two days and per day otherwise
+func (m *model) openTimeline() {
+	times := m.visibleTimes()
+	m.timelineHourly = len(times) > 0 && times[len(times)-1].Sub(times[0]) < 48*time.Hour
+	m.showTimeline = true
+}
+
+// visibleTimes returns the times of the events shown in the table, oldest first
+func (m model) visibleTimes() []time.Time {
+	var times []time.Time
+	for _, i := range m.visible {
+		if i < 0 || i >= len(m.events) {
+			continue
+		}
+		t := m.events[i].CreatedAt
+		if m.utc {
+			t = t.UTC()
+		} else {
+			t = t.Local()
+		}
+		times = append(times, t)
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	return times
+}
+
+func (m model) updateTimeline(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "ctrl+c":
+			return m, tea.Quit
+		case msg.String() == "esc", key.Matches(msg, m.keys.Timeline), key.Matches(msg, m.keys.Quit):
+			m.showTimeline = false
+		case msg.String() == "d":
+			m.timelineHourly = !m.timelineHourly
+		}
+	}
+	return m, nil
+}
+
+// timelineBucket truncates t to its hour or day
+func timelineBucket(t time.Time, hourly bool) time.Time {
+	if hourly {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// nextBucket returns the hour or day after bucket
+func nextBucket(bucket time.Time, hourly bool) time.Time {
+	if hourly {
+		return bucket.Add(time.Hour)
+	}
+	return bucket.AddDate(0, 0, 1)
+}
+
+// timelineCounts counts the events in each of the last n hours or days up to the
+// newest event, returning the start of each bucket with its count
+func timelineCounts(times []time.Time, hourly bool, n int) ([]time.Time, []int) {
+	if len(times) == 0 || n <= 0 {
+		return nil, nil
+	}
+	var buckets []time.Time
+	last := timelineBucket(times[len(times)-1], hourly)
+	for b := timelineBucket(times[0], hourly); !b.After(last); b = nextBucket(b, hourly) {
+		buckets = append(buckets, b)
+	}
+	buckets = buckets[max(len(buckets)-n, 0):]
+	counts := make([]int, len(buckets))
+	for _, t := range times {
+		if i, found := slices.BinarySearchFunc(buckets, timelineBucket(t, hourly), time.Time.Compare); found {
+			counts[i]++
+		}
+	}
+	return buckets, counts
+}
+
+// barCell is the part of a bar of height level (in eighths of a line) drawn on row
+// (counting up from 0)
+func barCell(level, row int) string {
+	filled := min(max(level-row*8, 0), 8)
+	if asciiConsole {
+		switch {
+		case filled >= 4:
+			return "#"
+		case filled > 0:
+			return "."
+		}
+		return " "
+	}
+	if filled == 0 {
+		return " "
+	}
+	return barBlocks[filled]
+}
+
+// timelineLabel labels a bucket on the time axis
+func timelineLabel(bucket time.Time, hourly bool) string {
+	if hourly && bucket.Hour() != 0 {
+		return bucket.Format("15:04")
+	}
+	return bucket.Format("Jan 2")
+}
+
+// timelineView renders the events as bars along a time axis so bursts of activity stand out
+func (m model) timelineView() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.Accent)
+	dim := lipgloss.NewStyle().Foreground(currentTheme.Dim)
+	bar := lipgloss.NewStyle().Foreground(currentTheme.Accent)
+
+	unit, other := "day", "hour"
+	if m.timelineHourly {
+		unit, other = "hour", "day"
+	}
+	footer := fmt.Sprintf("  d per %s • esc back", other)
+
+	times := m.visibleTimes()
+	if len(times) == 0 {
+		return "No events to show on the timeline\n\n" + footer + "\n"
+	}
+
+	// Room for the count axis on the left and a margin on the right
+	const axisWidth = 6
+	avail := max(m.termWidth()-axisWidth-4, 10)
+	buckets, counts := timelineCounts(times, m.timelineHourly, avail)
+	colWidth := min(max(avail/len(buckets), 1), 3)
+	peak := slices.Max(counts)
+
+	tick, line := "┤", "│"
+	if asciiConsole {
+		tick, line = "|", "|"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title.Render(fmt.Sprintf("Timeline of %s's %d events, per %s", m.username, len(times), unit)) + "\n\n")
+	for row := timelineHeight - 1; row >= 0; row-- {
+		switch row {
+		case timelineHeight - 1:
+			sb.WriteString(dim.Render(fmt.Sprintf("%*d %s", axisWidth-2, peak, tick)))
+		case 0:
+			sb.WriteString(dim.Render(fmt.Sprintf("%*d %s", axisWidth-2, 0, tick)))
+		default:
+			sb.WriteString(dim.Render(strings.Repeat(" ", axisWidth-1) + line))
+		}
+		var bars strings.Builder
+		for _, n := range counts {
+			level := n * timelineHeight * 8 / peak
+			if n > 0 {
+				// Don't let the quietest buckets disappear
+				level = max(level, 1)
+			}
+			bars.WriteString(strings.Repeat(barCell(level, row), colWidth))
+		}
+		sb.WriteString(bar.Render(bars.String()) + "\n")
+	}
+
+	// Label the axis wherever there's room for it
+	axis := []rune(strings.Repeat(" ", len(buckets)*colWidth))
+	next := 0
+	for i, b := range buckets {
+		label := []rune(timelineLabel(b, m.timelineHourly))
+		pos := i * colWidth
+		if pos < next || pos+len(label) > len(axis) {
+			continue
+		}
+		copy(axis[pos:], label)
+		next = pos + len(label) + 2
+	}
+	sb.WriteString(strings.Repeat(" ", axisWidth) + dim.Render(strings.TrimRight(string(axis), " ")) + "\n\n")
+
+	busiest := slices.Index(counts, peak)
+	busiestLabel := buckets[busiest].Format("Mon Jan 2")
+	if m.timelineHourly {
+		busiestLabel = buckets[busiest].Format("Mon Jan 2 15:04")
+	}
+	sb.WriteString(fmt.Sprintf("  busiest %s: %s (%d events)", unit, busiestLabel, peak))
+	if timelineBucket(times[0], m.timelineHourly).Before(buckets[0]) {
+		sb.WriteString(dim.Render(fmt.Sprintf(" • showing the last %d %ss", len(buckets), unit)))
+	}
+	return lipgloss.NewStyle().Padding(1, 0).Render(sb.String()) + "\n" + footer + "\n"
+}
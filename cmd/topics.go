@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// repoContext is a repository's primary language and topics, shown in the detail
+// view with --enrich to give some context for unfamiliar repositories
+type repoContext struct {
+	Language string
+	Topics   []string
+}
+
+// repoContextCache keeps the repositories looked up for the detail view, keyed by full name
+var repoContextCache sync.Map
+
+// Message type for a repository looked up for the detail view
+type fetchRepoContextMsg struct {
+	repo string
+	err  error
+}
+
+// languageColors are GitHub's colors for the most common languages
+var languageColors = map[string]string{
+	"C":                "#555555",
+	"C#":               "#178600",
+	"C++":              "#f34b7d",
+	"CSS":              "#563d7c",
+	"Dart":             "#00b4ab",
+	"Dockerfile":       "#384d54",
+	"Elixir":           "#6e4a7e",
+	"Go":               "#00add8",
+	"HTML":             "#e34c26",
+	"Haskell":          "#5e5086",
+	"Java":             "#b07219",
+	"JavaScript":       "#f1e05a",
+	"Jupyter Notebook": "#da5b0b",
+	"Kotlin":           "#a97bff",
+	"Lua":              "#000080",
+	"Makefile":         "#427819",
+	"Nix":              "#7e7eff",
+	"Objective-C":      "#438eff",
+	"PHP":              "#4f5d95",
+	"Python":           "#3572a5",
+	"Ruby":             "#701516",
+	"Rust":             "#dea584",
+	"Scala":            "#c22d40",
+	"Shell":            "#89e051",
+	"Swift":            "#f05138",
+	"TypeScript":       "#3178c6",
+	"Vue":              "#41b883",
+	"Zig":              "#ec915c",
+}
+
+var topicStyle = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("#0969da")).Background(lipgloss.Color("#ddf4ff"))
+
+// fetchRepoContextCmd looks up the language and topics of a repository for the detail view
+func fetchRepoContextCmd(api eventsAPI, fullName string) tea.Cmd {
+	if _, ok := repoContextCache.Load(fullName); ok {
+		return nil
+	}
+	return func() tea.Msg {
+		owner, name, ok := strings.Cut(fullName, "/")
+		if !ok {
+			return fetchRepoContextMsg{repo: fullName, err: fmt.Errorf("invalid repository name: %s", fullName)}
+		}
+		repo, _, err := api.GetRepository(context.Background(), owner, name)
+		if err != nil {
+			return fetchRepoContextMsg{repo: fullName, err: fmt.Errorf("failed to get repository %s: %v", fullName, err)}
+		}
+		repoContextCache.Store(fullName, &repoContext{Language: repo.GetLanguage(), Topics: repo.Topics})
+		return fetchRepoContextMsg{repo: fullName}
+	}
+}
+
+// repoContextBadges renders the cached language and topics of a repository as badges
+func repoContextBadges(fullName string) string {
+	cached, ok := repoContextCache.Load(fullName)
+	if !ok {
+		return ""
+	}
+	rc := cached.(*repoContext)
+	var badges []string
+	if rc.Language != "" {
+		dot := "●"
+		if asciiConsole {
+			dot = "*"
+		}
+		color := currentTheme.Dim
+		if c, ok := languageColors[rc.Language]; ok {
+			color = lipgloss.Color(c)
+		}
+		badges = append(badges, lipgloss.NewStyle().Foreground(color).Render(dot)+" "+rc.Language)
+	}
+	for _, topic := range rc.Topics {
+		badges = append(badges, topicStyle.Render(topic))
+	}
+	return strings.Join(badges, " ")
+}
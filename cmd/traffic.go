@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-github/v66/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trafficRepos     int
+	trafficReferrers int
+)
+
+// trafficDays is how far back the traffic API goes
+const trafficDays = 14
+
+// repoTraffic is the last 14 days of traffic of a repository
+type repoTraffic struct {
+	Repo      string
+	Views     *github.TrafficViews
+	Clones    *github.TrafficClones
+	Referrers []*github.TrafficReferrer
+}
+
+// adminRepos returns the repositories the token can administer (only admins can
+// see traffic), most starred and forked first
+func adminRepos(ctx context.Context, client *github.Client) ([]*github.Repository, error) {
+	repos, err := popularRepos(ctx, client, "all", "owner,collaborator,organization_member")
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(repos, func(r *github.Repository) bool { return !r.GetPermissions()["admin"] }), nil
+}
+
+// fetchTraffic gets the views, clones and top referrers of owner/repo
+func fetchTraffic(ctx context.Context, client *github.Client, owner, repo string) (repoTraffic, error) {
+	t := repoTraffic{Repo: owner + "/" + repo}
+	perDay := &github.TrafficBreakdownOptions{Per: "day"}
+	var err error
+	if t.Views, _, err = client.Repositories.ListTrafficViews(ctx, owner, repo, perDay); err != nil {
+		return t, fmt.Errorf("failed to get views of %s: %v", t.Repo, err)
+	}
+	if t.Clones, _, err = client.Repositories.ListTrafficClones(ctx, owner, repo, perDay); err != nil {
+		return t, fmt.Errorf("failed to get clones of %s: %v", t.Repo, err)
+	}
+	if t.Referrers, _, err = client.Repositories.ListTrafficReferrers(ctx, owner, repo); err != nil {
+		return t, fmt.Errorf("failed to get referrers of %s: %v", t.Repo, err)
+	}
+	return t, nil
+}
+
+// dailyCounts spreads the per day traffic over the last 14 days, oldest first, with
+// zeros for the days the API leaves out
+func dailyCounts(data []*github.TrafficData, now time.Time) []int {
+	byDay := make(map[string]int, len(data))
+	for _, d := range data {
+		byDay[d.GetTimestamp().UTC().Format(time.DateOnly)] = d.GetCount()
+	}
+	counts := make([]int, trafficDays)
+	today := now.UTC()
+	for i := range counts {
+		counts[i] = byDay[today.AddDate(0, 0, i-trafficDays+1).Format(time.DateOnly)]
+	}
+	return counts
+}
+
+// writeTraffic prints a small table of views, clones and referrers per repository,
+// busiest first, and a line for the ones without traffic
+func writeTraffic(w io.Writer, traffic []repoTraffic, referrers int, now time.Time) {
+	slices.SortStableFunc(traffic, func(a, b repoTraffic) int {
+		return cmp.Compare(b.Views.GetCount()+b.Clones.GetCount(), a.Views.GetCount()+a.Clones.GetCount())
+	})
+	var quiet []string
+	for _, t := range traffic {
+		if t.Views.GetCount() == 0 && t.Clones.GetCount() == 0 {
+			quiet = append(quiet, t.Repo)
+			continue
+		}
+		fmt.Fprintln(w, t.Repo)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "  views\t%7s  %7s unique\t%s\n", humanize.Comma(int64(t.Views.GetCount())),
+			humanize.Comma(int64(t.Views.GetUniques())), sparkline(dailyCounts(t.Views.Views, now)))
+		fmt.Fprintf(tw, "  clones\t%7s  %7s unique\t%s\n", humanize.Comma(int64(t.Clones.GetCount())),
+			humanize.Comma(int64(t.Clones.GetUniques())), sparkline(dailyCounts(t.Clones.Clones, now)))
+		for _, r := range t.Referrers[:min(len(t.Referrers), referrers)] {
+			fmt.Fprintf(tw, "  %s\t%7s  %7s unique\n", r.GetReferrer(),
+				humanize.Comma(int64(r.GetCount())), humanize.Comma(int64(r.GetUniques())))
+		}
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+	if len(quiet) > 0 {
+		fmt.Fprintf(w, "No traffic: %s\n", strings.Join(quiet, ", "))
+	}
+}
+
+var trafficCmd = &cobra.Command{
+	Use:   "traffic [owner/repo...]",
+	Short: "Show the views, clones and referrers of your repositories",
+	Long: `Show the views, clones and top referrers of the last 14 days of the given
+repositories, or of the most popular ones the token can administer (only admins
+can see the traffic of a repository), with a sparkline per day:
+
+  gitfamous traffic
+  gitfamous traffic blacktop/ipsw blacktop/go-gitfamous`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conf, _, err := setupSubcommand(nil)
+		if err != nil {
+			return err
+		}
+		setupConsole()
+		user, err := meUser(conf)
+		if err != nil {
+			return err
+		}
+		client, err := newClient(user.Token, user.Host)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		names := args
+		if len(names) == 0 {
+			repos, err := adminRepos(ctx, client)
+			if err != nil {
+				return fmt.Errorf("failed to list repositories: %v", err)
+			}
+			for _, repo := range repos[:min(len(repos), trafficRepos)] {
+				names = append(names, repo.GetFullName())
+			}
+		}
+		var traffic []repoTraffic
+		for _, name := range names {
+			owner, repo, ok := strings.Cut(name, "/")
+			if !ok || owner == "" || repo == "" {
+				return fmt.Errorf("invalid repository %q: expected owner/repo", name)
+			}
+			t, err := fetchTraffic(ctx, client, owner, repo)
+			if err != nil {
+				return err
+			}
+			traffic = append(traffic, t)
+		}
+		writeTraffic(os.Stdout, traffic, trafficReferrers, time.Now())
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trafficCmd)
+	trafficCmd.Flags().IntVar(&trafficRepos, "repos", 10, "Number of repositories to show without arguments, most starred first")
+	trafficCmd.Flags().IntVar(&trafficReferrers, "referrers", 3, "Number of top referrers to show per repository")
+	trafficCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	trafficCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	trafficCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	trafficCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// baseTransport is the transport used by all GitHub API clients (see configureTransport)
+var baseTransport http.RoundTripper = http.DefaultTransport
+
+// configureTransport sets up the HTTP proxy and extra trusted CA certificates for API requests
+func configureTransport(proxy, caCert string) error {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL %s: %v", proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate %s: %v", caCert, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no valid certificates found in %s", caCert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	baseTransport = transport
+	return nil
+}
+
+// middleware wraps the transport of the API clients
+type middleware func(http.RoundTripper) http.RoundTripper
+
+// apiMiddleware are extra layers around the API transport, outermost first
+// (e.g. to record or stub requests when testing against an httptest server)
+var apiMiddleware []middleware
+
+// apiTransport is the transport of the API clients. From the outside in it sets the
+// User-Agent, makes requests conditional, retries, shares in-flight GETs, waits for the
+// rate limiter and logs each request before it goes out over baseTransport.
+func apiTransport() http.RoundTripper {
+	var rt http.RoundTripper = baseTransport
+	if traceHTTP {
+		rt = traceTransport{base: rt}
+	}
+	rt = logTransport{base: rt}
+	rt = limitTransport{base: rt}
+	rt = dedupTransport{base: rt}
+	rt = newRetryTransport(rt, retryAttempts, retryBackoff)
+	rt = conditionalTransport{base: rt}
+	rt = userAgentTransport{base: rt}
+	for i := len(apiMiddleware) - 1; i >= 0; i-- {
+		rt = apiMiddleware[i](rt)
+	}
+	return rt
+}
+
+// userAgent identifies us to the API as GitHub asks clients to
+func userAgent() string {
+	return "gitfamous/" + version() + " (+https://github.com/blacktop/go-gitfamous)"
+}
+
+// userAgentTransport sets the User-Agent of API requests
+type userAgentTransport struct {
+	base http.RoundTripper
+}
+
+func (t userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", userAgent())
+	return t.base.RoundTrip(req)
+}
+
+// maxRetryWait caps how long we'll sleep for a single Retry-After
+const maxRetryWait = time.Minute
+
+// retryTransport retries requests that fail with transient errors using exponential backoff
+type retryTransport struct {
+	base     http.RoundTripper
+	attempts int
+	backoff  time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, attempts int, backoff time.Duration) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{
+		base:     base,
+		attempts: max(attempts, 1),
+		backoff:  backoff,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt < t.attempts; attempt++ {
+		// RoundTrippers mustn't modify the request, so retries send a copy with a fresh body
+		r := req
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.Body != nil {
+				if r.Body, err = req.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		resp, err = t.base.RoundTrip(r)
+		replayable := req.Body == nil || req.GetBody != nil
+		if !replayable || !shouldRetry(resp, err) || attempt == t.attempts-1 {
+			break
+		}
+		wait := t.backoff << attempt
+		if resp != nil {
+			if ra, ok := retryAfter(resp); ok {
+				wait = ra
+			}
+			// Drain so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		logger.Debug("retrying request", "url", req.URL.String(), "attempt", attempt+1, "wait", wait, "error", retryReason(resp, err))
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(min(wait, maxRetryWait)):
+		}
+	}
+	return resp, err
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return transientError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusInternalServerError:
+		return true
+	case http.StatusForbidden:
+		// Secondary rate limits come back as 403 with a Retry-After
+		return resp.Header.Get("Retry-After") != ""
+	}
+	return false
+}
+
+// transientError reports whether a failed request may work when retried: it timed
+// out or the connection was reset or refused. Cancelled requests and permanent
+// failures (untrusted certificates, unknown hosts, bad proxies, ...) aren't retried.
+func transientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// retryAfter parses the Retry-After header (seconds or HTTP date)
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+// ifNoneMatchKey is the context key of the ETag to send with a request (see withIfNoneMatch)
+type ifNoneMatchKey struct{}
+
+// withIfNoneMatch makes the requests made with ctx conditional on the ETag,
+// so they come back as 304 Not Modified (and don't count against the rate limit)
+// when nothing changed
+func withIfNoneMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifNoneMatchKey{}, etag)
+}
+
+// conditionalTransport adds the If-None-Match header set with withIfNoneMatch
+type conditionalTransport struct {
+	base http.RoundTripper
+}
+
+func (t conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	etag, _ := req.Context().Value(ifNoneMatchKey{}).(string)
+	if etag == "" {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("If-None-Match", etag)
+	return t.base.RoundTrip(req)
+}
+
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("HTTP %d", resp.StatusCode)
+}
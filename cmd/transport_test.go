@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// roundTripFunc is an http.RoundTripper calling the function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportKeepsRequest(t *testing.T) {
+	var bodies []string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		status := http.StatusServiceUnavailable
+		if len(bodies) == 3 {
+			status = http.StatusOK
+		}
+		return &http.Response{StatusCode: status, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+	req, err := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader("query"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := req.Body
+
+	resp, err := newRetryTransport(base, 3, 0).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d after retrying, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if want := []string{"query", "query", "query"}; strings.Join(bodies, ",") != strings.Join(want, ",") {
+		t.Errorf("attempts sent %q, want %q", bodies, want)
+	}
+	if req.Body != body {
+		t.Error("RoundTrip() replaced the body of the caller's request")
+	}
+}
+
+func TestRetryTransportRetriesTransientErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		attempts int
+	}{
+		{"connection refused", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, 3},
+		{"connection reset", &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, 3},
+		{"timeout", &net.DNSError{Err: "i/o timeout", Name: "api.github.com", IsTimeout: true}, 3},
+		{"unknown host", &net.DNSError{Err: "no such host", Name: "api.github.example", IsNotFound: true}, 1},
+		{"untrusted certificate", &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}, 1},
+		{"cancelled", context.Canceled, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: tt.err}
+			})
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com/users/octocat", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := newRetryTransport(base, 3, 0).RoundTrip(req); err == nil {
+				t.Fatal("RoundTrip() succeeded, want the error")
+			}
+			if attempts != tt.attempts {
+				t.Errorf("%d attempts, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
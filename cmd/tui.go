@@ -1,20 +1,33 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
 	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/dustin/go-humanize"
+	"github.com/expr-lang/expr/vm"
 	"github.com/google/go-github/v66/github"
 	"golang.org/x/term"
 )
@@ -30,237 +43,1318 @@ type Repo struct {
 }
 
 type eventItem struct {
-	Date        string
+	CreatedAt   time.Time
 	Type        string
 	Actor       *Actor
 	Repository  *Repo
 	Description string
+	Event       *github.Event
+	Coalesced   int // number of events summarized by this row
+	Unread      bool
+	Stats       *diffStats // set by --enrich
+	CI          ciStatus   // set by --ci
 }
 
 type model struct {
-	username    string
-	apiToken    string
-	events      []eventItem
-	table       table.Model
-	err         error
-	count       int
-	since       time.Duration
-	filterTypes []string // New field for filter criteria
-	tableHeight int
+	username     string
+	apiToken     string
+	host         string
+	events       []eventItem
+	visible      []int // indexes into events in table order
+	table        table.Model
+	tableStyles  table.Styles
+	keys         keyMap
+	help         help.Model
+	err          error
+	fetch        fetchOptions
+	cachedAt     time.Time // when the displayed events were cached (offline mode)
+	fetchedAt    time.Time
+	etag         string      // of the displayed events, to make refreshes conditional
+	rate         github.Rate // API rate limit as of the last fetch
+	streak       streak
+	timeFormat   string
+	timeLayouts  []string
+	utc          bool
+	enterAction  string
+	repoColumn   string
+	typeColumn   bool
+	sparklines   bool
+	tableHeight  int
+	descOffset   int      // horizontal scroll of the Description column
+	rowURLs      []string // event URL of each table row for hyperlinks
+	descOverflow int
+	width        int
+	height       int
+	pager        viewport.Model
+	pagerItem    eventItem
+	showPager    bool
+
+	notifications     string // unread notification count of the token's user (--me)
+	showNotifications bool
+	readOnly          bool // served over ssh (see viewOptions)
+
+	spinner      spinner.Model
+	loading      bool
+	loadingSince time.Time
+	fetchCtx     context.Context
+	cancelFetch  context.CancelFunc
+	fetchID      int        // ignores results from superseded fetches
+	next         pageCursor // where to load more events from (see paging.go)
+	loadingMore  bool
+
+	lastSeen   time.Time // newest event seen in the previous run
+	seenLoaded bool
+
+	userPrompt     textinput.Model
+	userChoices    []string // config and recently viewed users for the prompt
+	userCursor     int      // selected choice, -1 for the typed name
+	showUserPrompt bool
+
+	commits        table.Model
+	commitItems    []commitItem
+	commitsRepo    string
+	commitsLoading bool
+	showCommits    bool
+
+	repo     *repoInfo
+	repoName string
+	showRepo bool
+
+	bookmarks      []bookmark
+	bookmarkIDs    map[string]bool
+	bookmarksTable table.Model
+	showBookmarks  bool
+
+	showTimeline   bool
+	timelineHourly bool // per hour rather than per day
+
+	search      textinput.Model
+	searchQuery string
+	searchTexts []string     // lowercased search text of each event (see indexSearch)
+	searchHits  map[int]bool // indexes into events matching searchQuery
+	showSearch  bool
+
+	confirm *confirmMsg
+
+	blurred     bool // unfocused pane in split mode
+	showHelp    bool
+	showLegend  bool
+	palette     paletteModel
+	showPalette bool
+	groupByRepo bool
+	typeFilter  string // only events of this type (see toggleTypeFilter)
+	status      string
+
+	showAchievements bool
+
+	undoViews []viewState // view changes to undo, oldest first (see history.go)
+	redoViews []viewState
+
+	lastClick    time.Time
+	lastClickRow int
 }
 
 var baseTableStyle = lipgloss.NewStyle().
 	BorderStyle(lipgloss.NormalBorder()).
 	BorderForeground(lipgloss.Color("240"))
 
-func initialModel(username, apiToken string, count int, since time.Duration, filterTypes []string) model {
+var offlineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("214"))
+
+// viewOptions are the display settings shared by every events view
+type viewOptions struct {
+	TimeFormat  string
+	UTC         bool
+	EnterAction string // what enter does on a row: "browser" or "repo"
+	RepoColumn  string // how repositories are shown (see columns.go)
+	TypeColumn  bool   // show the event icons in their own Type column
+	Sparklines  bool   // show the Activity column (see sparkline.go)
+	// Notifications shows the unread notification count of the token's user (--me)
+	Notifications bool
+	// ReadOnly is for sessions served over ssh: no actions as the token's user,
+	// browsers or files on the server
+	ReadOnly bool
+}
+
+func initialModel(user User, fetch fetchOptions, opts viewOptions) model {
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = timeFormatRelative
+	}
+	layouts := []string{timeFormatRelative, timeFormatRFC3339}
+	if !slices.Contains(layouts, opts.TimeFormat) {
+		layouts = append(layouts, opts.TimeFormat) // custom layout
+	}
+	keys := defaultKeyMap()
+	if opts.EnterAction == enterActionRepo {
+		keys.Open.SetHelp("enter", "repo details")
+	}
+	if opts.ReadOnly {
+		for _, k := range []*key.Binding{&keys.Browser, &keys.Star, &keys.Follow, &keys.Bookmark, &keys.Bookmarks, &keys.Pin, &keys.Mute, &keys.Screenshot, &keys.SwitchUser} {
+			k.SetEnabled(false)
+		}
+	}
+	fetch.Host, fetch.Token = user.Host, user.Token
+	ctx, cancel := context.WithCancel(context.Background())
 	return model{
-		username:    username,
-		apiToken:    apiToken,
-		count:       count,
-		since:       since,
-		filterTypes: filterTypes,
+		username:    user.Name,
+		apiToken:    user.Token,
+		host:        user.Host,
+		fetch:       fetch,
+		timeFormat:  opts.TimeFormat,
+		timeLayouts: layouts,
+		utc:         opts.UTC,
+		enterAction: opts.EnterAction,
+		repoColumn:  opts.RepoColumn,
+		typeColumn:  opts.TypeColumn,
+		sparklines:  opts.Sparklines,
+		keys:        keys,
+		help:        help.New(),
+		spinner:     spinner.New(spinner.WithSpinner(loadingSpinner()), spinner.WithStyle(lipgloss.NewStyle().Foreground(currentTheme.Accent))),
+
+		showNotifications: opts.Notifications,
+		readOnly:          opts.ReadOnly,
+
+		loading:      true,
+		loadingSince: time.Now(),
+		fetchCtx:     ctx,
+		cancelFetch:  cancel,
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return m.fetchEventsCmd()
+	return tea.Batch(m.spinner.Tick, m.fetchEventsCmd(), m.notificationsCmd())
 }
 
 // Message type for fetched events
 type fetchEventsMsg struct {
-	events []eventItem
-	err    error
+	username string
+	received bool // for the received events tab of --me
+	fetchID  int
+	feed     *eventFeed
+	err      error
 }
 
 func (m model) fetchEventsCmd() tea.Cmd {
+	ctx, id := withQueueUser(m.fetchCtx, m.username), m.fetchID
 	return func() tea.Msg {
-		events, err := fetchEvents(m.username, m.apiToken, m.count, m.since, m.filterTypes)
+		opts := m.fetch
+		opts.Count = m.fetchCount()
+		if len(m.events) > 0 && m.etag != "" {
+			// Only refetch if something changed, and then only the newer events
+			opts.ETag = m.etag
+			opts.Known = make(map[string]bool, len(m.events))
+			for _, item := range m.events {
+				opts.Known[item.Event.GetID()] = true
+			}
+		}
+		feed, err := fetchEvents(ctx, m.api(), m.username, opts)
 		return fetchEventsMsg{
-			events: events,
-			err:    err,
+			username: m.username,
+			received: m.fetch.Received,
+			fetchID:  id,
+			feed:     feed,
+			err:      err,
 		}
 	}
 }
 
+// refetch starts fetching the events again, cancelling any fetch in flight
+func (m *model) refetch() tea.Cmd {
+	m.cancelFetch()
+	m.fetchCtx, m.cancelFetch = context.WithCancel(context.Background())
+	m.fetchID++
+	m.loading = true
+	m.loadingSince = time.Now()
+	return tea.Batch(m.spinner.Tick, m.fetchEventsCmd(), m.notificationsCmd())
+}
+
+// cancelLoading stops the fetch in flight (if any)
+func (m *model) cancelLoading() {
+	m.cancelFetch()
+	m.loading = false
+	if len(m.events) == 0 {
+		m.err = errFetchCancelled
+	} else {
+		m.status = "refresh cancelled"
+	}
+}
+
+var errFetchCancelled = errors.New("fetching events cancelled")
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	switch msg := msg.(type) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Handled before the overlays so the spinner keeps going underneath them
+		if !m.loading && !m.loadingMore {
+			return m, nil
+		}
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	if msg, ok := msg.(loadMoreMsg); ok {
+		// Also handled before the overlays so the events aren't lost
+		if msg.fetchID != m.fetchID {
+			return m, nil
+		}
+		m.appendEvents(msg)
+		return m, m.prefetch()
+	}
 
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Handled before the overlays too, which would drop them
+		m.resize(msg)
+		return m, nil
 	case fetchEventsMsg:
+		return m.handleFetchEvents(msg)
+	}
+
+	if msg, ok := msg.(notificationsMsg); ok {
 		if msg.err != nil {
-			m.err = msg.err
-			return m, tea.Quit
+			logger.Debug("counting notifications", "error", msg.err)
 		}
-		m.events = msg.events
+		m.notifications = msg.count
+		return m, nil
+	}
 
-		maxColWidths := map[string][]int{
-			"Date":        {},
-			"Repository":  {},
-			"Description": {},
-		}
-		// Create table rows
-		var rows []table.Row
-		for _, event := range m.events {
-			maxColWidths["Date"] = append(maxColWidths["Date"], len(event.Date))
-			maxColWidths["Repository"] = append(maxColWidths["Repository"], len(event.Repository.Name))
-			maxColWidths["Description"] = append(maxColWidths["Description"], len(event.Description))
-			row := table.Row{event.Date, event.Repository.Name, event.Description}
-			rows = append(rows, row)
+	if m.showUserPrompt {
+		return m.updateUserPrompt(msg)
+	}
+	if m.showPager {
+		return m.updatePager(msg)
+	}
+	if m.showPalette {
+		return m.updatePalette(msg)
+	}
+	if m.showSearch {
+		return m.updateSearch(msg)
+	}
+	if m.confirm != nil {
+		return m.updateConfirm(msg)
+	}
+	if m.showCommits {
+		return m.updateCommits(msg)
+	}
+	if m.showRepo {
+		return m.updateRepo(msg)
+	}
+	if m.showBookmarks {
+		return m.updateBookmarks(msg)
+	}
+	if m.showTimeline {
+		return m.updateTimeline(msg)
+	}
+
+	switch msg := msg.(type) {
+
+	case tea.MouseMsg:
+		if m.showHelp || m.showLegend || m.showAchievements || len(m.events) == 0 {
+			return m, nil
 		}
+		m, cmd = m.handleMouse(msg)
+		return m, tea.Batch(cmd, m.prefetch())
 
-		// Get terminal width
-		width, _, err := term.GetSize(int(os.Stdout.Fd()))
-		if err != nil {
-			width = 80 // Default width if there's an error
+	case confirmMsg:
+		m.confirm = &msg
+		return m, nil
+
+	case actionDoneMsg:
+		if msg.err != nil {
+			m.status = msg.err.Error()
+		} else {
+			m.status = msg.status
 		}
+		return m, nil
 
-		// Calculate max widths of columns based on content
-		dateWidth := slices.Max(maxColWidths["Date"])
-		repoWidth := slices.Max(maxColWidths["Repository"])
+	case tea.KeyMsg:
+		if m.showHelp || m.showLegend || m.showAchievements {
+			// Any key dismisses the help, legend and achievements overlays
+			m.showHelp, m.showLegend, m.showAchievements = false, false, false
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		if m.err != nil {
+			return m.updateError(msg)
+		}
+		m.status = ""
+		if m.loading && msg.String() == "esc" {
+			m.cancelLoading()
+			return m, nil
+		}
+		if m.searchQuery != "" && msg.String() == "esc" {
+			m.clearSearch()
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Open):
+			if m.enterAction == enterActionRepo {
+				return m, m.openRepo()
+			}
+			m.handleEnterKey()
+		case key.Matches(msg, m.keys.Browser):
+			m.handleEnterKey()
+		case key.Matches(msg, m.keys.Details):
+			return m, m.openPager()
+		case key.Matches(msg, m.keys.Star):
+			return m, m.toggleStarCmd()
+		case key.Matches(msg, m.keys.ScrollLeft):
+			m.scrollDescription(-descScrollStep)
+			return m, nil
+		case key.Matches(msg, m.keys.ScrollRight):
+			m.scrollDescription(descScrollStep)
+			return m, nil
+		case key.Matches(msg, m.keys.Search):
+			return m, m.openSearch()
+		case key.Matches(msg, m.keys.NextMatch):
+			m.jumpToMatch(1)
+			return m, nil
+		case key.Matches(msg, m.keys.PrevMatch):
+			m.jumpToMatch(-1)
+			return m, nil
+		case key.Matches(msg, m.keys.TypeFilter):
+			m.toggleTypeFilter()
+			return m, nil
+		case key.Matches(msg, m.keys.Screenshot):
+			base, err := saveScreenshot(m.View(), m.username)
+			m.status = screenshotStatus(base, err)
+			return m, nil
+		case key.Matches(msg, m.keys.Bookmark):
+			m.toggleBookmark()
+			return m, nil
+		case key.Matches(msg, m.keys.Bookmarks):
+			m.openBookmarks()
+			return m, nil
+		case key.Matches(msg, m.keys.Pin):
+			m.togglePin()
+			return m, nil
+		case key.Matches(msg, m.keys.Mute):
+			m.muteRepo()
+			return m, nil
+		case key.Matches(msg, m.keys.Undo):
+			m.undoView()
+			return m, nil
+		case key.Matches(msg, m.keys.Redo):
+			m.redoView()
+			return m, nil
+		case key.Matches(msg, m.keys.SwitchUser):
+			return m, m.openUserPrompt()
+		case key.Matches(msg, m.keys.Follow):
+			return m, m.toggleFollowCmd()
+		case key.Matches(msg, m.keys.Commits):
+			return m, m.openCommits()
+		case key.Matches(msg, m.keys.TimeFormat):
+			m.toggleTimeFormat()
+			return m, nil
+		case key.Matches(msg, m.keys.Timeline):
+			m.openTimeline()
+			return m, nil
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = true
+			return m, nil
+		case key.Matches(msg, m.keys.Legend):
+			m.showLegend = true
+			return m, nil
+		case key.Matches(msg, m.keys.Badges):
+			return m, m.openAchievements()
+		case key.Matches(msg, m.keys.LoadMore):
+			return m, m.loadMore()
+		case key.Matches(msg, m.keys.Refresh):
+			return m, m.refetch()
+		case m.atBottom(msg):
+			if cmd := m.loadMore(); cmd != nil {
+				return m, cmd
+			}
+		case key.Matches(msg, m.keys.Palette):
+			m.palette = newPalette(m.readOnly)
+			m.showPalette = true
+			return m, textinput.Blink
+		}
+	}
 
-		// Calculate spacing (adjust based on your table's formatting)
-		spacing := 4 // Adjust this value based on actual padding and separators in your table
+	// Update the table with any unhandled messages
+	m.table, cmd = m.table.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		return m, tea.Batch(cmd, m.prefetch())
+	}
+	return m, cmd
+}
 
-		// Define the desired right padding (in number of spaces)
-		rightPadding := spacing * 3 // Adjust this value as needed
+// resize fits the events table and the pager to the terminal
+func (m *model) resize(msg tea.WindowSizeMsg) {
+	m.width = msg.Width
+	m.height = msg.Height
+	m.pager.Width = msg.Width
+	m.pager.Height = msg.Height - 2
+	if len(m.events) > 0 {
+		m.setupTable()
+	}
+}
 
-		// Calculate Description column width to fill remaining terminal width minus right padding
-		descWidth := width - dateWidth - repoWidth - spacing - rightPadding
-		if descWidth < 20 { // Set a minimum width for Description
-			descWidth = 20
+// handleFetchEvents shows the events (or the error) of a fetch
+func (m model) handleFetchEvents(msg fetchEventsMsg) (tea.Model, tea.Cmd) {
+	if msg.fetchID != m.fetchID || errors.Is(msg.err, context.Canceled) {
+		// Superseded or cancelled with esc
+		return m, nil
+	}
+	m.loading = false
+	if m.loadingMore {
+		// Superseded by this fetch so drop the loading row
+		m.loadingMore = false
+		m.setupTable()
+	}
+	if msg.err != nil {
+		if len(m.events) > 0 {
+			// Keep showing the events we have
+			title, _ := describeFetchError(msg.err, m.username)
+			m.status = "refresh failed: " + strings.ToLower(title)
+			return m, nil
 		}
+		m.err = msg.err
+		return m, nil
+	}
+	m.fetchedAt = msg.feed.FetchedAt
+	m.rate = msg.feed.Rate
+	if msg.feed.NotModified {
+		m.status = "no new events"
+		return m, nil
+	}
+	if msg.feed.Incremental {
+		m.mergeNewEvents(msg.feed)
+		return m, nil
+	}
+	m.events = msg.feed.Items
+	m.etag = msg.feed.ETag
+	m.next = msg.feed.Next
+	m.cachedAt = msg.feed.CachedAt
+	m.streak = msg.feed.Streak
+	m.loadBookmarkIDs()
+	m.markUnread()
+	m.searchTexts = nil
+	m.updateSearchMatches()
+	if len(msg.feed.Warnings) > 0 {
+		m.status = strings.Join(msg.feed.Warnings, "; ")
+	}
+	m.setupTable()
+	return m, m.prefetch()
+}
+
+// bannerHeight is the number of lines rendered above the table
+func (m model) bannerHeight() int {
+	if !m.cachedAt.IsZero() {
+		return 1
+	}
+	return 0
+}
+
+// capturingInput reports whether an overlay is consuming key presses
+func (m model) capturingInput() bool {
+	return m.showPalette || m.showPager || m.showUserPrompt || m.showSearch || m.confirm != nil
+}
 
-		// Define table columns with calculated widths
-		columns := []table.Column{
-			{Title: "Date", Width: dateWidth + spacing},
-			{Title: "Repository", Width: repoWidth + spacing},
-			{Title: "Description", Width: descWidth},
+// termWidth returns the width available to the model
+func (m model) termWidth() int {
+	if m.width > 0 {
+		return m.width
+	}
+	// Get terminal width
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 80 // Default width if there's an error
+	}
+	return width
+}
+
+// setupTable (re)builds the events table from the fetched events
+func (m *model) setupTable() {
+	defer traceTiming("setup table", time.Now(), "user", m.username, "events", len(m.events))
+	maxColWidths := map[string][]int{
+		"Date":        {},
+		"Repository":  {},
+		"Description": {},
+	}
+	// Create table rows
+	var rows []table.Row
+	var sparks map[string]string
+	if m.sparklines {
+		sparks = m.repoSparklines()
+	}
+	compact := m.compact()
+	split := m.repoColumn == repoColumnSplit && !compact
+	// ASCII consoles have no icons to show
+	typeCol := m.typeColumn && !asciiConsole
+	m.visible = m.visibleEvents()
+	for _, idx := range m.visible {
+		event := m.events[idx]
+		date, repo := m.formatDate(event.CreatedAt), event.Repository.Name
+		switch {
+		case compact:
+			date, repo = m.shortDate(event.CreatedAt), m.shortRepo(repo)
+		case m.repoColumn == repoColumnShort:
+			repo = m.ownRepo(repo)
+		}
+		if event.Unread {
+			date = unreadMarker + date
+		}
+		repoCells := table.Row{repo}
+		if split {
+			var owner string
+			owner, repo = splitRepo(repo)
+			repoCells = table.Row{owner, repo}
+			maxColWidths["Owner"] = append(maxColWidths["Owner"], lipgloss.Width(owner))
+		}
+		maxColWidths["Date"] = append(maxColWidths["Date"], lipgloss.Width(date))
+		maxColWidths["Repository"] = append(maxColWidths["Repository"], lipgloss.Width(repo))
+		desc := consoleText(event.Description)
+		var icon string
+		if typeCol {
+			icon, desc = splitIcon(desc)
+			maxColWidths["Type"] = append(maxColWidths["Type"], lipgloss.Width(icon))
+		}
+		if event.Event != nil && m.bookmarkIDs[event.Event.GetID()] {
+			desc = bookmarkMarker + desc
+		}
+		if pinned(event.Repository.Name) {
+			desc = pinnedMarker + desc
+		}
+		if m.searchHits[idx] {
+			desc = searchMarker + desc
 		}
+		maxColWidths["Description"] = append(maxColWidths["Description"], lipgloss.Width(desc))
+		row := append(append(table.Row{date}, repoCells...), desc)
+		if m.sparklines {
+			row = slices.Insert(row, len(row)-1, sparks[event.Repository.Name])
+		}
+		if typeCol {
+			row = slices.Insert(row, len(row)-1, icon)
+		}
+		rows = append(rows, row)
+	}
+	// A row with just a message in the Description column
+	messageRow := func(msg string) table.Row {
+		row := table.Row{"", "", msg}
+		if split {
+			row = slices.Insert(row, 1, "")
+		}
+		if m.sparklines {
+			row = slices.Insert(row, len(row)-1, "")
+		}
+		if typeCol {
+			row = slices.Insert(row, len(row)-1, "")
+		}
+		return row
+	}
+	switch {
+	case m.loadingMore:
+		rows = append(rows, messageRow("loading older events..."))
+	case len(m.visible) == 0:
+		// Everything is muted or filtered out
+		msg := "no events to show"
+		if len(m.undoViews) > 0 {
+			msg += " (ctrl+z to undo)"
+		}
+		rows = append(rows, messageRow(msg))
+	}
+	m.rowURLs = nil
+	if hyperlinks {
+		for _, idx := range m.visible {
+			m.rowURLs = append(m.rowURLs, eventURL(m.events[idx], m.webURL()))
+		}
+	}
 
-		m.tableHeight = len(rows) + 1
-		if m.tableHeight > 30 {
-			m.tableHeight = 30
+	width := m.termWidth()
+
+	// Calculate max widths of columns based on content (within the config's layout),
+	// or their title without any rows
+	widest := func(column, title string) int {
+		if len(maxColWidths[column]) == 0 {
+			return lipgloss.Width(title)
 		}
+		return slices.Max(maxColWidths[column])
+	}
+	dateWidth := currentLayout.fit("date", widest("Date", "Date"), width)
+	repoWidth := currentLayout.fit("repository", widest("Repository", "Repository"), width)
+	var ownerWidth int
+	if split {
+		ownerWidth = currentLayout.fit("owner", widest("Owner", "Owner"), width)
+	}
+	typeWidth := max(widest("Type", "Type"), len("Type"))
 
-		// Initialize table model with updated columns
-		m.table = table.New(
-			table.WithColumns(columns),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(m.tableHeight),
-		)
+	// Calculate spacing (adjust based on your table's formatting)
+	spacing := 4 // Adjust this value based on actual padding and separators in your table
 
-		// Optional: Customize table styles
-		s := table.DefaultStyles()
-		s.Header = s.Header.
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			BorderBottom(true).
-			Foreground(lipgloss.Color("63")).
-			Bold(true)
-		s.Selected = s.Selected.
-			Foreground(lipgloss.Color("229")).
-			Background(lipgloss.Color("57")).
-			Bold(false)
-		m.table.SetStyles(s)
+	// Define the desired right padding (in number of spaces)
+	rightPadding := spacing * 3 // Adjust this value as needed
+	minDescWidth := 20
+	if compact {
+		// Every column counts on a narrow terminal: long repos are truncated to
+		// leave the description some room
+		spacing, rightPadding, minDescWidth = 1, 8, 10
+		repoWidth = min(repoWidth, max(width/3, 8))
+	}
 
-		return m, nil
+	// Calculate Description column width to fill remaining terminal width minus right padding
+	descWidth := width - dateWidth - repoWidth - spacing - rightPadding
+	if m.sparklines {
+		descWidth -= sparklineWidth + spacing
+	}
+	if split {
+		descWidth -= ownerWidth + spacing + 2 // and the cell padding of the extra column
+	}
+	if typeCol {
+		descWidth -= typeWidth + 2
+	}
+	if desc := currentLayout["description"]; desc.Ratio > 0 || desc.Max > 0 || desc.Min > 0 {
+		minDescWidth = max(desc.Min, 1)
+		descWidth = currentLayout.fit("description", descWidth, width)
+	}
+	if descWidth < minDescWidth { // Set a minimum width for Description
+		descWidth = minDescWidth
+	}
 
-	case tea.KeyMsg:
-		switch msg.String() {
-		// case "esc":
-		// 	if m.table.Focused() {
-		// 		m.table.Blur()
-		// 	} else {
-		// 		m.table.Focus()
-		// 	}
-		case "q", "ctrl+c":
-			return m, tea.Quit
-		case "enter":
-			m.handleEnterKey()
+	// Scroll long descriptions horizontally (see scrollDescription)
+	m.descOverflow = max(widest("Description", "Description")-descWidth, 0)
+	m.descOffset = min(m.descOffset, m.descOverflow)
+	if m.descOffset > 0 {
+		for i := range rows {
+			last := len(rows[i]) - 1
+			rows[i][last] = scrollText(rows[i][last], m.descOffset)
 		}
 	}
 
-	// Update the table with any unhandled messages
-	m.table, cmd = m.table.Update(msg)
-	return m, cmd
+	// Define table columns with calculated widths
+	columns := []table.Column{
+		{Title: "Date", Width: dateWidth + spacing},
+		{Title: "Repository", Width: repoWidth + spacing},
+		{Title: "Description", Width: descWidth},
+	}
+	if split {
+		columns[1].Title = "Repo"
+		columns = slices.Insert(columns, 1, table.Column{Title: "Owner", Width: ownerWidth + spacing})
+	}
+	if m.sparklines {
+		columns = slices.Insert(columns, len(columns)-1, table.Column{Title: "Activity", Width: sparklineWidth + spacing})
+	}
+	if typeCol {
+		columns = slices.Insert(columns, len(columns)-1, table.Column{Title: "Type", Width: typeWidth})
+	}
+
+	m.tableHeight = len(rows) + 1
+	if m.tableHeight > 30 {
+		m.tableHeight = 30
+	}
+
+	// Initialize table model with updated columns (keeping the cursor across rebuilds)
+	cursor := m.table.Cursor()
+	m.table = table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(!m.blurred),
+		table.WithHeight(m.tableHeight),
+		table.WithKeyMap(m.keys.KeyMap),
+	)
+
+	m.applyTableStyles()
+	m.table.MoveDown(cursor)
+}
+
+const (
+	timeFormatRelative = "relative"
+	timeFormatRFC3339  = "rfc3339"
+)
+
+// formatDate renders an event timestamp using the active time format
+func (m model) formatDate(t time.Time) string {
+	if m.utc {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+	switch m.timeFormat {
+	case timeFormatRelative:
+		return humanTime(t)
+	case timeFormatRFC3339:
+		return t.Format(time.RFC3339)
+	default:
+		return t.Format(m.timeFormat)
+	}
+}
+
+// descScrollStep is how many columns h/l scroll the descriptions by
+const descScrollStep = 10
+
+// scrollDescription scrolls the Description column so long rows can be read in place
+func (m *model) scrollDescription(delta int) {
+	offset := min(max(m.descOffset+delta, 0), m.descOverflow)
+	if offset == m.descOffset {
+		return
+	}
+	m.descOffset = offset
+	m.setupTable()
+}
+
+// scrollText drops the first offset columns of s, marking that it's been scrolled
+func scrollText(s string, offset int) string {
+	runes := []rune(s)
+	if offset >= len(runes) {
+		return ""
+	}
+	return "…" + string(runes[offset+1:])
+}
+
+// toggleTimeFormat cycles through relative, RFC3339 and the custom layout (if any)
+func (m *model) toggleTimeFormat() {
+	if len(m.events) == 0 {
+		// Still loading
+		return
+	}
+	idx := slices.Index(m.timeLayouts, m.timeFormat)
+	m.timeFormat = m.timeLayouts[(idx+1)%len(m.timeLayouts)]
+	m.setupTable()
 }
 
 func (m model) View() string {
+	defer traceRender("events", time.Now())
 	if m.err != nil {
-		return fmt.Sprintf("Error: %v\n", m.err)
+		return m.errorView()
 	}
 
 	if len(m.events) == 0 {
-		return "Loading events...\n"
+		elapsed := time.Since(m.loadingSince).Truncate(time.Second)
+		return fmt.Sprintf("%s Loading events for %s... %s (esc to cancel)\n", m.spinner.View(), m.username, elapsed)
 	}
 
-	return baseTableStyle.Render(m.table.View()) + "\n  " + m.table.HelpView() + "\n"
+	if m.showPager {
+		return m.pagerView()
+	}
+
+	if m.showHelp {
+		return m.helpView()
+	}
+
+	if m.showLegend {
+		return m.legendView()
+	}
+
+	if m.showAchievements {
+		return m.achievementsView()
+	}
+
+	if m.showCommits {
+		return m.commitsView()
+	}
+
+	if m.showRepo {
+		return m.repoView()
+	}
+
+	if m.showBookmarks {
+		return m.bookmarksView()
+	}
+
+	if m.showTimeline {
+		return m.timelineView()
+	}
+
+	style := baseTableStyle
+	if m.blurred {
+		style = style.BorderForeground(currentTheme.BorderBlurred)
+	}
+	view := style.Render(m.stripeRows(m.colorRows(m.linkRows(m.table.View()))))
+	if !m.cachedAt.IsZero() {
+		view = offlineStyle.Render(fmt.Sprintf(" offline • showing events cached %s ", humanTime(m.cachedAt))) + "\n" + view
+	}
+	if m.showPalette {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, m.paletteView())
+	}
+	if m.confirm != nil {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, m.confirmView())
+	}
+	if m.showUserPrompt {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, m.userPromptView())
+	}
+	if m.showSearch {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, m.searchView())
+	}
+	footer := m.help.View(m.keys)
+	if m.compact() {
+		footer = m.compactHelp()
+	}
+	if m.status != "" {
+		footer = m.status
+	}
+	return view + "\n" + m.statusBarView() + "\n  " + footer + "\n"
 }
 
-func fetchEvents(username, api string, count int, since time.Duration, filterTypes []string) ([]eventItem, error) {
-	ctx := context.Background()
+// helpView renders the full-screen keybinding overlay
+func (m model) helpView() string {
+	h := m.help
+	h.ShowAll = true
+	title := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.Accent).Render("Keybindings")
+	return lipgloss.NewStyle().Padding(1, 2).Render(title+"\n\n"+h.View(m.keys)) + "\n\n  press any key to close\n"
+}
 
-	client := github.NewClient(nil).WithAuthToken(api)
+// visibleEvents returns the indexes of the events to display in table order
+func (m model) visibleEvents() []int {
+	idxs := make([]int, 0, len(m.events))
+	for i := range m.events {
+		// Hide repos muted since the fetch
+		if mutedRepo(m.fetch.MutedRepos, m.events[i].Repository.Name) {
+			continue
+		}
+		if m.typeFilter != "" && m.events[i].Type != m.typeFilter {
+			continue
+		}
+		idxs = append(idxs, i)
+	}
+	if m.groupByRepo {
+		slices.SortStableFunc(idxs, func(a, b int) int {
+			return strings.Compare(m.events[a].Repository.Name, m.events[b].Repository.Name)
+		})
+	}
+	if anyPinned() {
+		// Pinned repos first
+		slices.SortStableFunc(idxs, func(a, b int) int {
+			pa, pb := pinned(m.events[a].Repository.Name), pinned(m.events[b].Repository.Name)
+			switch {
+			case pa && !pb:
+				return -1
+			case pb && !pa:
+				return 1
+			}
+			return 0
+		})
+	}
+	return idxs
+}
 
-	opt := &github.ListOptions{}
+// selectedEvent returns the event under the table cursor
+func (m model) selectedEvent() (eventItem, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return eventItem{}, false
+	}
+	return m.events[m.visible[cursor]], true
+}
 
-	var allEvents []*github.Event
-	var fetchedCount int
+func (m *model) toggleGroupByRepo() {
+	m.pushView()
+	m.groupByRepo = !m.groupByRepo
+	m.setupTable()
+}
+
+// fetchOptions control which events are fetched for a user
+type fetchOptions struct {
+	Count       int
+	Since       timeBound
+	Until       timeBound
+	FilterTypes []string
+	IgnoreUsers []string       // hide the events of these actors
+	MutedRepos  []string       // hide the events of these repositories
+	Grep        *regexp.Regexp // only keep events whose description or payload matches
+	GrepV       *regexp.Regexp // drop events whose description or payload matches
+	Expr        *vm.Program    // compiled --expr (see compileExpr)
+	Coalesce    bool
+	Offline     bool      // only use cached events
+	Replay      *fixture  // serve events from a --replay fixture
+	Record      string    // --record fixture to save fetched events to
+	Provider    Provider  // custom event source when embedded (see library.go)
+	API         eventsAPI // stands in for the GitHub API, nil for the token's client
+	PerPage     int
+	Enrich      bool       // fetch diff stats (see enrich.go)
+	CIStatus    bool       // fetch the CI status of pushes and PRs
+	Sort        string     // --sort order (see sort.go)
+	From        pageCursor // continue paging from here (see loadMore)
+	ETag        string     // of the previous fetch, to skip the fetch when nothing changed (see errNotModified)
+	Received    bool       // fetch the events the user received (from who and what they follow) instead of their own
+	NoCache     bool       // leave the event cache and streak archive alone (library fetches)
+	Host        string     // the user's GitHub host, which keys their event cache (see cachePath)
+	Token       string     // the user's token, which keys its cached scopes (see tokenScopes)
+
+	IncludePrivate bool
+	Known          map[string]bool // IDs of the events shown, to only fetch newer ones on refresh (see fetchEventsCmd)
+}
+
+// eventFeed is the result of fetching a user's events
+type eventFeed struct {
+	Items     []eventItem
+	CachedAt  time.Time // set when the events were loaded from the cache
+	FetchedAt time.Time
+	Rate      github.Rate
+	Warnings  []string
+	Next      pageCursor // where to continue for more events, zero when there are none
+	ETag      string     // of the first page of events
+	// NotModified is set when nothing changed since the fetch of opts.ETag
+	// (and Items is empty)
+	NotModified bool
+	Streak      streak // of the user's contributions, see updateStreak
+	// Incremental is set when Items are only the events newer than opts.Known,
+	// to merge at the top of the ones shown
+	Incremental bool
+}
+
+// errNotModified is returned by listEvents when the events haven't changed since opts.ETag
+var errNotModified = errors.New("events not modified")
+
+// pageCursor is a position in the events API pagination
+type pageCursor struct {
+	Page    int // API page, 0 for the first (or, as a feed's Next, when there are no more)
+	Offset  int // events of Page already seen
+	PerPage int // the page size the position refers to
+}
+
+// fetchEvents fetches a user's events and sorts them by opts.Sort
+func fetchEvents(ctx context.Context, api eventsAPI, username string, opts fetchOptions) (*eventFeed, error) {
+	defer traceTiming("fetch", time.Now(), "user", username)
+	feed, err := fetchFeed(ctx, api, username, opts)
+	if err != nil {
+		return nil, err
+	}
+	sortEvents(feed.Items, opts.Sort)
+	return feed, nil
+}
+
+func fetchFeed(ctx context.Context, api eventsAPI, username string, opts fetchOptions) (*eventFeed, error) {
+	if opts.Offline {
+		return fetchCachedEvents(username, opts)
+	}
+	if opts.Replay != nil {
+		return replayEvents(username, opts)
+	}
+	if opts.Provider != nil {
+		return providerEvents(ctx, username, opts)
+	}
 
+	feed := &eventFeed{}
+	publicOnly := true
+	if opts.IncludePrivate {
+		ok, warning := canSeePrivateEvents(ctx, api, opts.Token, opts.Host, username)
+		publicOnly = !ok
+		if warning != "" {
+			feed.Warnings = append(feed.Warnings, warning)
+		}
+	}
+
+	var err error
+	more := opts.From.Page > 0
+	if !more && opts.ETag == "" {
+		// Catch typos up front rather than reporting "no events found"
+		// (a refresh was already checked by the fetch that got the ETag)
+		err = checkUser(ctx, api, username)
+	}
+	var rawEvents, allEvents []*github.Event
+	var rate github.Rate
+	if err == nil {
+		rawEvents, allEvents, feed.Next, feed.ETag, rate, err = listEvents(ctx, api, username, publicOnly, opts)
+	}
+	if errors.Is(err, errNotModified) {
+		logger.Debug("events not modified", "user", username)
+		feed.NotModified, feed.ETag, feed.Rate, feed.FetchedAt = true, opts.ETag, rate, time.Now()
+		return feed, nil
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if isNetworkError(err) && !more && !opts.NoCache {
+			if cached, cerr := fetchCachedEvents(username, opts); cerr == nil {
+				logger.Debug("network unavailable, using cached events", "error", err)
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+	feed.Rate = rate
+	// A refresh that got back to the events shown only has to merge the newer ones,
+	// otherwise (e.g. more new events than fit) they're all replaced
+	feed.Incremental = len(opts.Known) > 0 && slices.ContainsFunc(rawEvents, func(event *github.Event) bool {
+		return opts.Known[event.GetID()]
+	})
+
+	// Only the first fetch of the user's own events is cached and recorded, not the
+	// events loaded on demand (or stubbed, received or library ones)
+	if !more && opts.API == nil && !opts.Received && !opts.NoCache {
+		save := saveCache
+		if feed.Incremental {
+			save = mergeCache
+		}
+		if err := save(username, opts.Host, rawEvents); err != nil {
+			logger.Debug("failed to cache events", "error", err)
+		}
+		if feed.Streak, err = updateStreak(username, rawEvents); err != nil {
+			logger.Debug("failed to update streak", "error", err)
+		}
+		// The fixture keeps the full first fetch
+		if opts.Record != "" && !feed.Incremental {
+			if err := recordEvents(opts.Record, username, rawEvents); err != nil {
+				feed.Warnings = append(feed.Warnings, fmt.Sprintf("failed to record events: %v", err))
+			}
+		}
+	}
+
+	feed.Items, err = toEventItems(username, allEvents, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Enrich || opts.CIStatus {
+		enrichEvents(ctx, api, feed.Items, opts)
+	}
+	feed.FetchedAt = time.Now()
+	return feed, nil
+}
+
+// listEvents pages through the user's events (newest first) and returns every event
+// fetched along with the selected ones. Paging stops as soon as enough events are
+// selected or the --since cutoff is passed, so no pages past the window are fetched.
+// It starts from opts.From and returns where to continue for more events, and the
+// ETag of the first page. With opts.ETag it returns errNotModified if the first page
+// (and so every page) is unchanged.
+func listEvents(ctx context.Context, api eventsAPI, username string, publicOnly bool, opts fetchOptions) (raw, selected []*github.Event, next pageCursor, etag string, rate github.Rate, err error) {
+	// Resolve relative bounds once so the window doesn't drift between pages
+	opts.Since = timeBound{abs: opts.Since.Time()}
+	opts.Until = timeBound{abs: opts.Until.Time()}
+
+	perPage := opts.PerPage
+	if opts.Count > 0 && len(opts.FilterTypes) == 0 && opts.Expr == nil && opts.Until.IsZero() {
+		// Every event is selected so don't fetch more than we need
+		if perPage == 0 || perPage > opts.Count {
+			perPage = min(opts.Count, 100)
+		}
+	}
+	if opts.From.PerPage > 0 {
+		// Keep the page size the cursor refers to
+		perPage = opts.From.PerPage
+	}
+	opt := &github.ListOptions{PerPage: perPage, Page: opts.From.Page}
+	offset := opts.From.Offset
+	first := opt.Page == 0
 	for {
-		events, resp, err := client.Activity.ListEventsPerformedByUser(ctx, username, true, opt) // true = public only
+		pageCtx := ctx
+		if first && opts.ETag != "" {
+			pageCtx = withIfNoneMatch(ctx, opts.ETag)
+		}
+		list := api.ListEventsPerformedByUser
+		if opts.Received {
+			list = api.ListEventsReceivedByUser
+		}
+		events, resp, err := list(pageCtx, username, publicOnly, opt)
 		if err != nil {
-			return nil, err
+			if first && resp != nil && resp.StatusCode == http.StatusNotModified {
+				return nil, nil, pageCursor{}, "", resp.Rate, errNotModified
+			}
+			var errResp *github.ErrorResponse
+			if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusUnprocessableEntity && (len(raw) > 0 || opts.From.Page > 0) {
+				// The events API only serves the most recent 300 events
+				logger.Debug("reached the end of the events API pagination", "user", username)
+				break
+			}
+			return nil, nil, pageCursor{}, "", rate, err
 		}
-		for _, event := range events {
-			if since > 0 {
-				if event.GetCreatedAt().Time.Before(time.Now().Add(-since)) {
-					break
+		rate = resp.Rate
+		if first {
+			etag, first = resp.Header.Get("ETag"), false
+		}
+		// Skip the events of the page seen by the previous fetch
+		page := events[min(offset, len(events)):]
+		raw = append(raw, page...)
+		if known := slices.IndexFunc(page, func(event *github.Event) bool { return opts.Known[event.GetID()] }); known >= 0 {
+			// The rest is already shown (see fetchFeed)
+			selected, _ = selectEvents(page[:known], opts, selected)
+			logger.Debug("stopping pagination: reached the events already shown", "user", username,
+				"page", max(opt.Page, 1), "selected", len(selected))
+			break
+		}
+		var done bool
+		selected, done = selectEvents(page, opts, selected)
+		logger.Debug("fetched events page", "user", username, "page", max(opt.Page, 1), "per_page", perPage,
+			"events", len(events), "selected", len(selected), "next_page", resp.NextPage)
+		if done {
+			logger.Debug("stopping pagination: enough events selected or --since cutoff passed", "user", username)
+			if opts.Count > 0 && len(selected) >= opts.Count {
+				// There may be more events after the last one selected
+				seen := offset + slices.Index(page, selected[len(selected)-1]) + 1
+				switch {
+				case seen < len(events):
+					next = pageCursor{Page: max(opt.Page, 1), Offset: seen, PerPage: perPage}
+				case resp.NextPage != 0:
+					next = pageCursor{Page: resp.NextPage, PerPage: perPage}
 				}
 			}
-			if len(filterTypes) > 0 {
-				if !slices.Contains(filterTypes, event.GetType()) {
-					continue
-				}
+			break
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+		offset = 0
+	}
+	return raw, selected, next, etag, rate, nil
+}
+
+// selectEvents appends the events matching opts to selected and reports
+// whether enough events have been selected (or the --since cutoff was passed)
+func selectEvents(events []*github.Event, opts fetchOptions, selected []*github.Event) ([]*github.Event, bool) {
+	sinceTime, untilTime := opts.Since.Time(), opts.Until.Time()
+	for _, event := range events {
+		if !sinceTime.IsZero() {
+			if event.GetCreatedAt().Time.Before(sinceTime) {
+				// Events are newest first so later pages are all older too
+				return selected, true
 			}
-			allEvents = append(allEvents, event)
-			fetchedCount++
-			if 0 < count && fetchedCount >= count {
-				break
+		}
+		if !untilTime.IsZero() {
+			if event.GetCreatedAt().Time.After(untilTime) {
+				continue
+			}
+		}
+		if len(opts.FilterTypes) > 0 {
+			if !slices.Contains(opts.FilterTypes, event.GetType()) {
+				continue
 			}
 		}
+		if slices.ContainsFunc(opts.IgnoreUsers, func(login string) bool {
+			return strings.EqualFold(login, event.GetActor().GetLogin())
+		}) {
+			continue
+		}
+		if mutedRepo(opts.MutedRepos, event.GetRepo().GetName()) {
+			continue
+		}
+		if (opts.Grep != nil || opts.GrepV != nil) && !grepEvent(event, opts.Grep, opts.GrepV) {
+			continue
+		}
+		if opts.Expr != nil && !matchExpr(opts.Expr, event) {
+			continue
+		}
+		selected = append(selected, event)
+		if 0 < opts.Count && len(selected) >= opts.Count {
+			return selected, true
+		}
+	}
+	return selected, false
+}
 
-		if (0 < count && fetchedCount >= count) || resp.NextPage == 0 {
-			break
+// grepEvent reports whether the description or raw payload of event matches grep
+// (when set) and doesn't match grepV (when set)
+func grepEvent(event *github.Event, grep, grepV *regexp.Regexp) bool {
+	text := getEventDescription(event) + "\n" + string(event.GetRawPayload())
+	if grep != nil && !grep.MatchString(text) {
+		return false
+	}
+	return grepV == nil || !grepV.MatchString(text)
+}
+
+// minParallelDescribe is how many events it takes to describe them in parallel
+const minParallelDescribe = 64
+
+// describeEvents returns the descriptions of events, parsing their payloads on a
+// pool of workers since that's most of the time spent before the first render
+func describeEvents(events []*github.Event) []string {
+	descs := make([]string, len(events))
+	if len(events) < minParallelDescribe {
+		for i, event := range events {
+			descs[i] = getEventDescription(event)
 		}
-		opt.Page = resp.NextPage
+		return descs
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(events)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				descs[i] = getEventDescription(events[i])
+			}
+		}()
+	}
+	for i := range events {
+		next <- i
 	}
+	close(next)
+	wg.Wait()
+	return descs
+}
 
-	// Process the events
+// toEventItems processes the selected events into table items
+func toEventItems(username string, events []*github.Event, opts fetchOptions) ([]eventItem, error) {
+	defer traceTiming("describe events", time.Now(), "user", username, "events", len(events))
+	descs := describeEvents(events)
 	var eventItems []eventItem
-	for _, event := range allEvents {
+	for i, event := range events {
 		item := eventItem{
-			Date:        humanize.Time(event.GetCreatedAt().Time),
+			CreatedAt:   event.GetCreatedAt().Time,
 			Type:        event.GetType(),
 			Actor:       &Actor{Login: event.GetActor().GetLogin(), AvatarURL: event.GetActor().GetAvatarURL()},
 			Repository:  &Repo{Name: event.GetRepo().GetName(), URL: event.GetRepo().GetURL()},
-			Description: getEventDescription(event),
+			Description: descs[i],
+			Event:       event,
 		}
 		eventItems = append(eventItems, item)
 	}
 
-	if len(eventItems) == 0 {
-		return nil, fmt.Errorf("no events found for user %s (since %s)", username, since)
+	if opts.Coalesce {
+		eventItems = coalesceEvents(eventItems)
+	}
+
+	if len(eventItems) == 0 && opts.From.Page == 0 && len(opts.Known) == 0 {
+		if !opts.Until.IsZero() {
+			return nil, fmt.Errorf("no events found for user %s (since %s, until %s)", username, opts.Since, opts.Until)
+		}
+		return nil, fmt.Errorf("no events found for user %s (since %s)", username, opts.Since)
 	}
 
 	return eventItems, nil
 }
 
+// newClient returns a GitHub API client for host (github.com or a GitHub Enterprise Server)
+func newClient(token, host string) (*github.Client, error) {
+	client := github.NewClient(&http.Client{Transport: apiTransport()}).WithAuthToken(token)
+	if host == "" || host == defaultHost {
+		return client, nil
+	}
+	return client.WithEnterpriseURLs("https://"+host+"/api/v3/", "https://"+host+"/api/uploads/")
+}
+
+// client returns the API client for the model's account
+func (m model) client() *github.Client {
+	client, err := newClient(m.apiToken, m.host)
+	if err != nil {
+		// The host is validated at startup so this shouldn't happen
+		logger.Error("creating GitHub client", "error", err)
+		client, _ = newClient(m.apiToken, defaultHost)
+	}
+	return client
+}
+
+// api returns the eventsAPI events are fetched from
+func (m model) api() eventsAPI {
+	if m.fetch.API != nil {
+		return m.fetch.API
+	}
+	return githubAPI{m.client()}
+}
+
+// webURL returns the base URL of the account's GitHub web UI
+func (m model) webURL() string {
+	if m.host == "" {
+		return "https://" + defaultHost
+	}
+	return "https://" + m.host
+}
+
 // Helper function to get a description based on event type
 func getEventDescription(event *github.Event) string {
+	if desc, ok := templateDescription(event); ok {
+		return desc
+	}
+	if event.GetType() == "SponsorshipEvent" {
+		// go-github can't parse the tier changes (it expects a string, not the tier object)
+		return sponsorshipIcon + " " + describeSponsorship(event.GetRawPayload())
+	}
 	payload, err := event.ParsePayload()
 	if err != nil {
 		return fmt.Sprintf("[ERROR] %v", err)
@@ -294,16 +1388,24 @@ func getEventDescription(event *github.Event) string {
 			return " Forked repository"
 		}
 	case "GollumEvent":
-		if _, ok := payload.(*github.GollumEvent); ok {
-			return fmt.Sprintf("󰷉 Wiki page event")
+		if payload, ok := payload.(*github.GollumEvent); ok {
+			return "󰷉 Wiki " + describeWikiPages(payload.Pages)
 		}
 	case "IssueCommentEvent":
 		if payload, ok := payload.(*github.IssueCommentEvent); ok {
-			return fmt.Sprintf("󰅽 Issue comment on #%d: %#v", payload.GetIssue().GetNumber(), payload.GetComment().GetBody())
+			return fmt.Sprintf("󰅽 Issue comment on #%d: %s", payload.GetIssue().GetNumber(), previewText(payload.GetComment().GetBody()))
 		}
 	case "IssuesEvent":
 		if payload, ok := payload.(*github.IssuesEvent); ok {
-			return fmt.Sprintf("󱋄 Issue #%d %s: %s", payload.GetIssue().GetNumber(), payload.GetAction(), payload.GetIssue().GetTitle())
+			issue := payload.GetIssue()
+			meta := issueMeta{issue.Labels, issue.Milestone, issue.Assignees}
+			desc := fmt.Sprintf("󱋄 Issue #%d %s: %s%s", issue.GetNumber(), payload.GetAction(), plainText(issue.GetTitle()), meta)
+			if payload.GetAction() == "opened" {
+				if body := previewText(issue.GetBody()); body != "" {
+					desc += " · " + body
+				}
+			}
+			return desc
 		}
 	case "MemberEvent":
 		if payload, ok := payload.(*github.MemberEvent); ok {
@@ -315,11 +1417,19 @@ func getEventDescription(event *github.Event) string {
 		}
 	case "PullRequestEvent":
 		if payload, ok := payload.(*github.PullRequestEvent); ok {
-			return fmt.Sprintf(" PR #%d %s", payload.GetNumber(), payload.GetAction())
+			pr := payload.GetPullRequest()
+			meta := issueMeta{pr.Labels, pr.Milestone, pr.Assignees}
+			icon, action := prState(payload)
+			return fmt.Sprintf("%s PR #%d %s%s", icon, payload.GetNumber(), action, meta)
 		}
 	case "PullRequestReviewEvent":
 		if payload, ok := payload.(*github.PullRequestReviewEvent); ok {
-			return fmt.Sprintf("  PR review on #%d", payload.GetPullRequest().GetNumber())
+			icon, state := reviewState(payload)
+			desc := fmt.Sprintf("%s %s PR #%d %s", prIcon, icon, payload.GetPullRequest().GetNumber(), state)
+			if reviewer := payload.GetReview().GetUser().GetLogin(); reviewer != "" {
+				desc += " by @" + reviewer
+			}
+			return desc
 		}
 	case "PullRequestReviewCommentEvent":
 		if payload, ok := payload.(*github.PullRequestReviewCommentEvent); ok {
@@ -339,43 +1449,211 @@ func getEventDescription(event *github.Event) string {
 		if payload, ok := payload.(*github.ReleaseEvent); ok {
 			return fmt.Sprintf("󰎔 Released %s", payload.GetRelease().GetName())
 		}
-	case "SponsorshipEvent":
-		if payload, ok := payload.(*github.SponsorshipEvent); ok {
-			return fmt.Sprintf(" Sponsorship event on %s", payload.GetRepository())
-		}
 	case "WatchEvent":
 		if _, ok := payload.(*github.WatchEvent); ok {
 			return "⭐️ Starred repository"
 		}
 	default:
-		return fmt.Sprintf("%#v", payload)
+		return describeUnknownEvent(event)
 	}
 	return ""
 }
 
+// describeWikiPages lists the pages of a GollumEvent by action (e.g. "created Setup; edited Home, FAQ")
+func describeWikiPages(pages []*github.Page) string {
+	var actions []string
+	titles := make(map[string][]string)
+	for _, page := range pages {
+		action := cmp.Or(page.GetAction(), "edited")
+		if _, ok := titles[action]; !ok {
+			actions = append(actions, action)
+		}
+		titles[action] = append(titles[action], cmp.Or(page.GetTitle(), page.GetPageName()))
+	}
+	if len(actions) == 0 {
+		return "page event"
+	}
+	var parts []string
+	for _, action := range actions {
+		parts = append(parts, action+" "+strings.Join(titles[action], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// sponsorshipPayload is a SponsorshipEvent payload (go-github leaves out the sponsorship)
+type sponsorshipPayload struct {
+	Action      string `json:"action"`
+	Sponsorship struct {
+		Sponsor     struct{ Login string } `json:"sponsor"`
+		Sponsorable struct{ Login string } `json:"sponsorable"`
+		Tier        sponsorshipTier        `json:"tier"`
+	} `json:"sponsorship"`
+	Changes struct {
+		Tier struct {
+			From sponsorshipTier `json:"from"`
+		} `json:"tier"`
+	} `json:"changes"`
+}
+
+type sponsorshipTier struct {
+	Name                  string `json:"name"`
+	MonthlyPriceInDollars int    `json:"monthly_price_in_dollars"`
+	IsOneTime             bool   `json:"is_one_time"`
+}
+
+func (t sponsorshipTier) String() string {
+	switch {
+	case t.Name != "":
+		return t.Name
+	case t.IsOneTime:
+		return fmt.Sprintf("$%d one time", t.MonthlyPriceInDollars)
+	}
+	return fmt.Sprintf("$%d a month", t.MonthlyPriceInDollars)
+}
+
+// describeSponsorship summarizes a SponsorshipEvent (e.g. "Sponsorship created: @alice sponsors @bob ($5 a month)")
+func describeSponsorship(raw []byte) string {
+	var p sponsorshipPayload
+	if err := json.Unmarshal(raw, &p); err != nil || p.Action == "" {
+		return "Sponsorship"
+	}
+	desc := "Sponsorship " + strings.ReplaceAll(p.Action, "_", " ")
+	s := p.Sponsorship
+	if s.Sponsor.Login != "" && s.Sponsorable.Login != "" {
+		desc += fmt.Sprintf(": @%s sponsors @%s", s.Sponsor.Login, s.Sponsorable.Login)
+	}
+	if s.Tier != (sponsorshipTier{}) {
+		if from := p.Changes.Tier.From; from != (sponsorshipTier{}) {
+			arrow := "→"
+			if asciiConsole {
+				arrow = "->"
+			}
+			desc += fmt.Sprintf(" (%s %s %s)", from, arrow, s.Tier)
+		} else {
+			desc += fmt.Sprintf(" (%s)", s.Tier)
+		}
+	}
+	return desc
+}
+
+// describeUnknownEvent summarizes an event type we don't know about (yet) from
+// the common fields of its raw payload
+func describeUnknownEvent(event *github.Event) string {
+	parts := []string{"❔ " + strings.TrimSuffix(event.GetType(), "Event")}
+	var fields map[string]any
+	if err := json.Unmarshal(event.GetRawPayload(), &fields); err == nil {
+		if action, ok := fields["action"].(string); ok && action != "" {
+			parts = append(parts, action)
+		}
+		if ref, ok := fields["ref"].(string); ok && ref != "" {
+			parts = append(parts, strings.TrimPrefix(ref, "refs/heads/"))
+		}
+		// The subject is usually a nested object like "issue" or "discussion"
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			obj, ok := fields[name].(map[string]any)
+			if !ok {
+				continue
+			}
+			title, _ := obj["title"].(string)
+			if title == "" {
+				title, _ = obj["name"].(string)
+			}
+			if title == "" {
+				continue
+			}
+			if number, ok := obj["number"].(float64); ok {
+				title = fmt.Sprintf("#%d %s", int(number), title)
+			}
+			parts = append(parts, title)
+			break
+		}
+	}
+	return strings.Join(parts, " ") + " (unsupported event type)"
+}
+
+// browserCommand is the config's browser (or $GITFAMOUS_BROWSER) used instead of the OS default
+var browserCommand string
+
+// browserCmd runs the custom browser command with the URL in place of %s (or appended).
+// The URL is passed as an argument rather than spliced into the shell command line.
+func browserCmd(command, url string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		args := strings.Fields(command)
+		if !slices.Contains(args, "%s") {
+			args = append(args, "%s")
+		}
+		for i, arg := range args {
+			args[i] = strings.ReplaceAll(arg, "%s", url)
+		}
+		return exec.Command(args[0], args[1:]...)
+	}
+	if strings.Contains(command, "%s") {
+		command = strings.ReplaceAll(command, "%s", `"$1"`)
+	} else {
+		command += ` "$1"`
+	}
+	return exec.Command("sh", "-c", command, "gitfamous", url)
+}
+
 // Function to open a URL in the default browser
 func openURL(url string) error {
 	var cmd *exec.Cmd
+	if browserCommand != "" {
+		return browserCmd(browserCommand, url).Start()
+	}
 
 	switch runtime.GOOS {
 	case "darwin":
 		cmd = exec.Command("open", url)
 	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		// cmd.exe treats & as a command separator, which cut URLs with query params short
+		cmd = exec.Command("cmd", "/c", "start", strings.ReplaceAll(url, "&", "^&"))
 	default: // "linux", "freebsd", "openbsd", "netbsd"
-		cmd = exec.Command("xdg-open", url)
+		switch {
+		case !isWSL():
+			cmd = exec.Command("xdg-open", url)
+		case hasCommand("wslview"):
+			cmd = exec.Command("wslview", url)
+		default:
+			// Hand the URL to the Windows side (WSL usually has no xdg-open or Linux browser)
+			cmd = exec.Command("powershell.exe", "-NoProfile", "-NonInteractive", "-Command",
+				"Start-Process '"+strings.ReplaceAll(url, "'", "''")+"'")
+		}
 	}
 
 	return cmd.Start()
 }
 
+// isWSL reports whether we're running under the Windows Subsystem for Linux
+func isWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	version, err := os.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(version)), "microsoft")
+}
+
+// hasCommand reports whether name is an executable in $PATH
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
 func (m *model) handleEnterKey() {
-	selectedRow := m.table.SelectedRow()
-	if selectedRow == nil {
+	// The repository column may be shortened (see compact.go)
+	item, ok := m.selectedEvent()
+	if !ok {
 		return
 	}
 
-	repoURL := "https://github.com/" + selectedRow[1]
+	if m.readOnly {
+		m.status = sshBrowserStatus
+		return
+	}
+	repoURL := m.webURL() + "/" + item.Repository.Name
 
 	// Validate URL
 	if _, err := url.ParseRequestURI(repoURL); err != nil {
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v66/github"
+)
+
+// testTime is when the test events happened
+var testTime = time.Date(2024, 11, 5, 14, 30, 0, 0, time.UTC)
+
+// setupTestHome keeps the caches, bookmarks and config the TUI reads and writes
+// in a temporary directory
+func setupTestHome(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	old := configPath
+	configPath = filepath.Join(home, "config.yml")
+	t.Cleanup(func() { configPath = old })
+}
+
+// testEvent returns an event of repo with the given payload, described like fetched ones
+func testEvent(id, typ, repo string, payload any) eventItem {
+	data, _ := json.Marshal(payload)
+	raw := json.RawMessage(data)
+	at := testTime.Add(-time.Duration(len(id)) * time.Hour)
+	event := &github.Event{
+		ID:         github.String(id),
+		Type:       github.String(typ),
+		Actor:      &github.User{Login: github.String("octocat")},
+		Repo:       &github.Repository{Name: github.String(repo)},
+		RawPayload: &raw,
+		CreatedAt:  &github.Timestamp{Time: at},
+	}
+	return eventItem{
+		CreatedAt:   at,
+		Type:        typ,
+		Actor:       &Actor{Login: "octocat"},
+		Repository:  &Repo{Name: repo},
+		Description: getEventDescription(event),
+		Event:       event,
+	}
+}
+
+// testEvents are a few events of two repositories
+func testEvents() []eventItem {
+	return []eventItem{
+		testEvent("3", "PushEvent", "octocat/hello-world", map[string]any{
+			"ref":     "refs/heads/main",
+			"commits": []any{map[string]any{"message": "Update README"}},
+		}),
+		testEvent("2", "WatchEvent", "blacktop/ipsw", map[string]any{"action": "started"}),
+		testEvent("1", "IssuesEvent", "octocat/hello-world", map[string]any{
+			"action": "opened",
+			"issue":  map[string]any{"number": 42, "title": "Found a bug"},
+		}),
+	}
+}
+
+// loadedModel returns the events view of octocat sized to width by height with
+// the events loaded
+func loadedModel(t *testing.T, items []eventItem, width, height int) model {
+	t.Helper()
+	m := initialModel(User{Name: "octocat"}, fetchOptions{}, viewOptions{TimeFormat: timeFormatRFC3339, UTC: true})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	updated, _ = updated.Update(fetchEventsMsg{username: "octocat", feed: &eventFeed{Items: items}, fetchID: updated.(model).fetchID})
+	return updated.(model)
+}
+
+func TestToggleTimeFormatWhileLoading(t *testing.T) {
+	setupTestHome(t)
+	m := initialModel(User{Name: "octocat"}, fetchOptions{}, viewOptions{})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if got := updated.(model).timeFormat; got != timeFormatRelative {
+		t.Errorf("time format = %q while loading, want %q", got, timeFormatRelative)
+	}
+}
+
+func TestSetupTableWithoutEvents(t *testing.T) {
+	setupTestHome(t)
+	m := loadedModel(t, testEvents(), 100, 30)
+	m.events = nil
+	m.setupTable()
+	if got := len(m.visible); got != 0 {
+		t.Errorf("visible = %d, want 0", got)
+	}
+}
+
+func TestRefreshUnderOverlay(t *testing.T) {
+	setupTestHome(t)
+	m := loadedModel(t, testEvents(), 100, 30)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if !updated.(model).showPager {
+		t.Fatal("v didn't open the pager")
+	}
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	items := testEvents()[:1]
+	updated, _ = updated.Update(fetchEventsMsg{username: "octocat", feed: &eventFeed{Items: items}, fetchID: updated.(model).fetchID})
+	m = updated.(model)
+	if m.loading || len(m.events) != 1 {
+		t.Errorf("loading = %v with %d events after the refresh under the pager, want false and 1", m.loading, len(m.events))
+	}
+	if m.width != 80 || m.height != 24 {
+		t.Errorf("size = %dx%d after resizing under the pager, want 80x24", m.width, m.height)
+	}
+}
+
+func TestReadOnlyCantSwitchUser(t *testing.T) {
+	setupTestHome(t)
+	m := initialModel(User{Name: "octocat"}, fetchOptions{}, viewOptions{ReadOnly: true})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	updated, _ = updated.Update(fetchEventsMsg{username: "octocat", feed: &eventFeed{Items: testEvents()}, fetchID: updated.(model).fetchID})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if updated.(model).showUserPrompt {
+		t.Error("u opened the user prompt of a read-only session")
+	}
+
+	p := newPalette(true)
+	p.filter()
+	for _, i := range p.matches {
+		if name := paletteCommands[i].Name; name == "switch user" {
+			t.Errorf("read-only palette offers %q", name)
+		}
+	}
+
+	m = updated.(model)
+	m.err = &unknownUserError{username: "octocta", suggestions: []string{"octocat"}}
+	for _, k := range []string{"u", "1"} {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		if updated.(model).showUserPrompt || cmd != nil {
+			t.Errorf("%s on the error screen of a read-only session switched user", k)
+		}
+	}
+}
+
+// stubbedModel returns the events view of octocat loaded from api
+func stubbedModel(t *testing.T, api stubAPI, opts viewOptions) model {
+	t.Helper()
+	var m tea.Model = initialModel(User{Name: "octocat"}, fetchOptions{API: api}, opts)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	feed, err := fetchEvents(context.Background(), api, "octocat", fetchOptions{API: api})
+	if err != nil {
+		t.Fatalf("fetchEvents() error = %v", err)
+	}
+	m, _ = m.Update(fetchEventsMsg{username: "octocat", feed: feed, fetchID: m.(model).fetchID})
+	return m.(model)
+}
+
+func TestRepoOverlayFromStubAPI(t *testing.T) {
+	setupTestHome(t)
+	api := newStubAPI(3)
+	api.Repos = map[string]*github.Repository{
+		"octocat/repo-3": {FullName: github.String("octocat/repo-3"), StargazersCount: github.Int(42)},
+	}
+	m := stubbedModel(t, api, viewOptions{EnterAction: enterActionRepo})
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !updated.(model).showRepo {
+		t.Fatal("enter didn't open the repo overlay")
+	}
+	updated, _ = updated.Update(cmd())
+	repo := updated.(model).repo
+	if repo == nil || repo.Name != "octocat/repo-3" || repo.Stars != 42 {
+		t.Errorf("repo = %+v, want octocat/repo-3 with 42 stars from the stub", repo)
+	}
+}
+
+func TestStarFromStubAPI(t *testing.T) {
+	setupTestHome(t)
+	api := newStubAPI(3)
+	api.Starred = map[string]bool{"octocat/repo-3": true}
+	m := stubbedModel(t, api, viewOptions{})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil {
+		t.Fatal("s didn't check the star")
+	}
+	msg, ok := cmd().(confirmMsg)
+	if !ok || msg.prompt != "Unstar octocat/repo-3?" {
+		t.Fatalf("s = %+v, want to confirm unstarring octocat/repo-3", msg)
+	}
+	if status, err := msg.action(); err != nil || status != "unstarred octocat/repo-3" {
+		t.Errorf("unstar = %q, %v", status, err)
+	}
+}
+
+func TestRepoOverlayOpensInItsTab(t *testing.T) {
+	setupTestHome(t)
+	api := newStubAPI(3)
+	api.Repos = map[string]*github.Repository{
+		"octocat/repo-3": {FullName: github.String("octocat/repo-3")},
+	}
+	tabs := []model{
+		stubbedModel(t, api, viewOptions{EnterAction: enterActionRepo}),
+		initialModel(User{Name: "hubot"}, fetchOptions{API: api}, viewOptions{}),
+	}
+	var m tea.Model = initialMultiUserModel(tabs, fetchOptions{}, viewOptions{})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter didn't look up the repo")
+	}
+	// Switch tabs while the repo is looked up
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(cmd())
+	multi := m.(multiUserModel)
+	if multi.active != 1 || multi.tabs[1].showRepo || multi.tabs[1].repo != nil {
+		t.Errorf("the repo opened in hubot's tab (active tab %d)", multi.active)
+	}
+	if repo := multi.tabs[0].repo; repo == nil || repo.Name != "octocat/repo-3" {
+		t.Errorf("repo of octocat's tab = %+v, want octocat/repo-3", repo)
+	}
+}
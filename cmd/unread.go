@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// unreadMarker is shown in front of events that are new since the last run
+var unreadMarker = "• "
+
+// seenPath is in its own directory so it isn't taken for the event cache of a user named seen
+func seenPath() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "unread", "seen.json"), nil
+}
+
+// loadSeen returns the time of the newest event seen for each user
+func loadSeen() (map[string]time.Time, error) {
+	fname, err := seenPath()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]time.Time)
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return seen, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &seen); err != nil {
+		return nil, err
+	}
+	return seen, nil
+}
+
+// markSeen records newest as the newest event seen for username
+func markSeen(username string, newest time.Time) error {
+	seen, err := loadSeen()
+	if err != nil {
+		return err
+	}
+	if !newest.After(seen[username]) {
+		return nil
+	}
+	seen[username] = newest
+	fname, err := seenPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(seen)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fname, data, 0o600)
+}
+
+// markUnread flags the events newer than the last run and records the newest one as seen
+func (m *model) markUnread() {
+	if !m.seenLoaded {
+		// Keep the previous run's marker for the whole session so refreshes don't clear it
+		seen, err := loadSeen()
+		if err != nil {
+			logger.Debug("loading seen events", "error", err)
+		}
+		m.lastSeen = seen[m.seenKey()]
+		m.seenLoaded = true
+	}
+	var newest time.Time
+	for i := range m.events {
+		// Nothing is new the first time a user is viewed
+		m.events[i].Unread = !m.lastSeen.IsZero() && m.events[i].CreatedAt.After(m.lastSeen)
+		if m.events[i].CreatedAt.After(newest) {
+			newest = m.events[i].CreatedAt
+		}
+	}
+	if err := markSeen(m.seenKey(), newest); err != nil {
+		logger.Debug("saving seen events", "error", err)
+	}
+}
+
+// unreadCount returns the number of events that are new since the last run
+func (m model) unreadCount() int {
+	var n int
+	for _, event := range m.events {
+		if event.Unread {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSeenIsNotARecentUser(t *testing.T) {
+	setupTestHome(t)
+	if err := saveCache("octocat", "", nil); err != nil {
+		t.Fatalf("saveCache() error = %v", err)
+	}
+	if err := markSeen("octocat", testTime); err != nil {
+		t.Fatalf("markSeen() error = %v", err)
+	}
+	if got := recentUsers(); !slices.Equal(got, []string{"octocat"}) {
+		t.Errorf("recentUsers() = %q, want only octocat", got)
+	}
+	seen, err := loadSeen()
+	if err != nil || !seen["octocat"].Equal(testTime) {
+		t.Errorf("loadSeen() = %v, %v, want octocat seen at %v", seen, err, testTime)
+	}
+}
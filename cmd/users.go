@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// maxSuggestions is the number of "did you mean" suggestions for unknown users
+const maxSuggestions = 3
+
+// unknownUserError is returned when the user to track doesn't exist
+type unknownUserError struct {
+	username    string
+	suggestions []string
+}
+
+func (e *unknownUserError) Error() string {
+	if len(e.suggestions) == 0 {
+		return fmt.Sprintf("GitHub user %q does not exist", e.username)
+	}
+	return fmt.Sprintf("GitHub user %q does not exist (did you mean %s?)", e.username, strings.Join(e.suggestions, ", "))
+}
+
+// viewerLogins caches the login of each token (by host and token)
+var viewerLogins sync.Map
+
+// viewerLogin returns the login of the user a token belongs to
+func viewerLogin(ctx context.Context, token, host string) (string, error) {
+	key := host + "\x00" + token
+	if login, ok := viewerLogins.Load(key); ok {
+		return login.(string), nil
+	}
+	client, err := newClient(token, host)
+	if err != nil {
+		return "", err
+	}
+	user, _, err := githubAPI{client}.GetUser(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	viewerLogins.Store(key, user.GetLogin())
+	return user.GetLogin(), nil
+}
+
+// checkUser makes sure username exists, suggesting similar logins when it doesn't
+func checkUser(ctx context.Context, api eventsAPI, username string) error {
+	_, _, err := api.GetUser(ctx, username)
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+		return err
+	}
+	return &unknownUserError{username: username, suggestions: suggestUsers(ctx, api, username)}
+}
+
+// suggestUsers searches for logins close to username (best match first)
+func suggestUsers(ctx context.Context, api eventsAPI, username string) []string {
+	queries := []string{username}
+	if len(username) > 3 {
+		// Catch a typo in the last character
+		queries = append(queries, username[:len(username)-1])
+	}
+	var logins []string
+	for _, q := range queries {
+		result, _, err := api.SearchUsers(ctx, q+" in:login", &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 10}})
+		if err != nil {
+			logger.Debug("searching for similar users", "query", q, "error", err)
+			continue
+		}
+		for _, user := range result.Users {
+			if login := user.GetLogin(); !slices.Contains(logins, login) {
+				logins = append(logins, login)
+			}
+		}
+	}
+	target := strings.ToLower(username)
+	slices.SortStableFunc(logins, func(a, b string) int {
+		return levenshtein(target, strings.ToLower(a)) - levenshtein(target, strings.ToLower(b))
+	})
+	return logins[:min(len(logins), maxSuggestions)]
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
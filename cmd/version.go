@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"runtime/debug"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// AppVersion is the version of the build (set with ldflags)
+	AppVersion string
+	// AppBuildCommit is the git commit of the build (set with ldflags)
+	AppBuildCommit string
+	// AppBuildDate is the date of the build (set with ldflags)
+	AppBuildDate string
+)
+
+// version returns the build version, falling back to the module info for `go install` builds
+func version() string {
+	if AppVersion != "" {
+		return AppVersion
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// buildCommit returns the git commit of the build (if known)
+func buildCommit() string {
+	if AppBuildCommit != "" {
+		return AppBuildCommit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
+
+// apiEndpoint returns the REST API base URL for host
+func apiEndpoint(host string) string {
+	if host == "" || host == defaultHost {
+		return "https://api.github.com/"
+	}
+	return "https://" + host + "/api/v3/"
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version and build info",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		date := AppBuildDate
+		if date == "" {
+			date = "unknown"
+		}
+		fmt.Printf("gitfamous %s\n", version())
+		fmt.Printf("  commit:  %s\n", buildCommit())
+		fmt.Printf("  built:   %s\n", date)
+		fmt.Printf("  go:      %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+		fmt.Printf("  api:     %s\n", apiEndpoint(defaultHost))
+		// Don't resolve tokens (no token_cmd) just to print the endpoints
+		if conf, err := readConfig(configPath); err == nil {
+			for _, name := range slices.Sorted(maps.Keys(conf.Accounts)) {
+				fmt.Printf("  api:     %s (account %s)\n", apiEndpoint(conf.Accounts[name].Host), name)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+// The views are compared with the snapshots in testdata, run the tests with
+// -update to regenerate them after changing a view on purpose
+
+func TestViewLoading(t *testing.T) {
+	setupTestHome(t)
+	m := initialModel(User{Name: "octocat"}, fetchOptions{}, viewOptions{})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(model)
+	m.loadingSince = time.Now()
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestViewLoaded(t *testing.T) {
+	setupTestHome(t)
+	m := loadedModel(t, testEvents(), 100, 20)
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestViewResized(t *testing.T) {
+	setupTestHome(t)
+	m := loadedModel(t, testEvents(), 100, 20)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 70, Height: 12})
+	golden.RequireEqual(t, []byte(updated.View()))
+}
+
+func TestViewError(t *testing.T) {
+	setupTestHome(t)
+	m := initialModel(User{Name: "octocat"}, fetchOptions{}, viewOptions{})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	updated, _ = updated.Update(fetchEventsMsg{username: "octocat", err: errors.New("boom"), fetchID: updated.(model).fetchID})
+	golden.RequireEqual(t, []byte(updated.View()))
+}
+
+// multiUserTestModel returns the tabs of octocat and blacktop sized to width by
+// height, with the events of octocat loaded
+func multiUserTestModel(t *testing.T, width, height int) tea.Model {
+	t.Helper()
+	opts := viewOptions{TimeFormat: timeFormatRFC3339, UTC: true}
+	tabs := []model{
+		initialModel(User{Name: "octocat"}, fetchOptions{}, opts),
+		initialModel(User{Name: "blacktop"}, fetchOptions{}, opts),
+	}
+	var m tea.Model = initialMultiUserModel(tabs, fetchOptions{}, opts)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	m, _ = m.Update(fetchEventsMsg{username: "octocat", feed: &eventFeed{Items: testEvents()}, fetchID: tabs[0].fetchID})
+	return m
+}
+
+func TestMultiUserViewLoaded(t *testing.T) {
+	setupTestHome(t)
+	m := multiUserTestModel(t, 100, 20)
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestMultiUserViewResized(t *testing.T) {
+	setupTestHome(t)
+	m := multiUserTestModel(t, 100, 20)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 70, Height: 12})
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestMultiUserViewLoading(t *testing.T) {
+	setupTestHome(t)
+	m := multiUserTestModel(t, 100, 20).(multiUserModel)
+	m.active = 1
+	m.tabs[1].loadingSince = time.Now()
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestMultiUserViewError(t *testing.T) {
+	setupTestHome(t)
+	m := multiUserTestModel(t, 100, 20).(multiUserModel)
+	updated, _ := m.Update(fetchEventsMsg{username: "blacktop", err: errors.New("boom"), fetchID: m.tabs[1].fetchID})
+	m = updated.(multiUserModel)
+	m.active = 1
+	golden.RequireEqual(t, []byte(m.View()))
+}
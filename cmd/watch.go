@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchInterval time.Duration
+	metricsAddr   string
+)
+
+// The watch metrics, only registered by the watch command (see registerMetrics) so
+// importing the package doesn't touch the default registry
+var (
+	eventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitfamous_events_total",
+		Help: "Number of events seen per user, event type and repository.",
+	}, []string{"user", "type", "repo"})
+	fetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gitfamous_fetch_duration_seconds",
+		Help:    "Time taken to fetch a user's events.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"user"})
+	fetchErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitfamous_fetch_errors_total",
+		Help: "Number of failed fetches per user.",
+	}, []string{"user"})
+	rateRemaining = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gitfamous_rate_limit_remaining",
+		Help: "GitHub API requests remaining as of the last fetch.",
+	})
+)
+
+// registerMetrics adds the watch metrics to the default registry served on /metrics
+func registerMetrics() {
+	prometheus.MustRegister(eventsTotal, fetchDuration, fetchErrors, rateRemaining)
+}
+
+// watcher polls a user's events and records the new ones in the metrics
+type watcher struct {
+	user  User
+	opts  fetchOptions
+	sinks []filteredSink
+	seen  map[string]bool // event IDs from the previous poll
+	etag  string          // of the previous poll, so unchanged feeds cost no rate limit
+}
+
+// poll fetches the user's events, logging and counting the ones not seen before
+func (w *watcher) poll(ctx context.Context) {
+	client, err := newClient(w.user.Token, w.user.Host)
+	if err != nil {
+		logger.Error("creating client", "user", w.user.Name, "error", err)
+		return
+	}
+	opts := w.opts
+	opts.ETag = w.etag
+	opts.Host = w.user.Host
+	start := time.Now()
+	feed, err := fetchEvents(ctx, githubAPI{client}, w.user.Name, opts)
+	fetchDuration.WithLabelValues(w.user.Name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		if ctx.Err() == nil {
+			fetchErrors.WithLabelValues(w.user.Name).Inc()
+			logger.Warn("fetching events", "user", w.user.Name, "error", err)
+		}
+		return
+	}
+	if feed.Rate.Limit > 0 {
+		rateRemaining.Set(float64(feed.Rate.Remaining))
+	}
+	if feed.NotModified {
+		logger.Debug("no new events", "user", w.user.Name)
+		return
+	}
+	w.etag = feed.ETag
+
+	seen := make(map[string]bool, len(feed.Items))
+	for i := len(feed.Items) - 1; i >= 0; i-- { // oldest first
+		item := feed.Items[i]
+		id := item.Event.GetID()
+		seen[id] = true
+		if w.seen[id] {
+			continue
+		}
+		eventsTotal.WithLabelValues(w.user.Name, item.Type, item.Repository.Name).Inc()
+		if w.seen != nil {
+			// Only log and notify what happened since the first poll
+			logger.Info(item.Description, "user", w.user.Name, "type", item.Type, "repo", item.Repository.Name)
+			notify(ctx, w.sinks, notification{User: w.user.Name, Item: item, URL: eventURL(item, w.webURL())})
+		}
+	}
+	w.seen = seen
+}
+
+// webURL returns the web address of the user's GitHub host
+func (w *watcher) webURL() string {
+	if w.user.Host == "" {
+		return "https://" + defaultHost
+	}
+	return "https://" + w.user.Host
+}
+
+var watchCmd = &cobra.Command{
+	Use:     "watch [username...]",
+	Aliases: []string{"serve"},
+	Short:   "Poll users' events in the background and expose Prometheus metrics",
+	Long: `Poll the users' events without the TUI, logging new events as they happen and
+serving Prometheus metrics (events per user/type/repo, fetch latencies and errors)
+on /metrics so contributor activity can be graphed in Grafana.
+
+New events are also sent to the Slack and Discord webhooks in the config's notify section.`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeUsernames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conf, users, err := setupSubcommand(args)
+		if err != nil {
+			return err
+		}
+		if len(filterTypes) == 0 {
+			filterTypes = conf.Filter
+		}
+		filter, err := parseFilterTypes(filterTypes)
+		if err != nil {
+			return err
+		}
+		sinks, err := loadSinks(conf)
+		if err != nil {
+			return err
+		}
+		if watchInterval < time.Minute {
+			// Stay well clear of the API rate limit
+			return errors.New("--interval must be at least 1m")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		registerMetrics()
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		srv := &http.Server{Addr: metricsAddr, Handler: mux}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("serving metrics", "error", err)
+				stop()
+			}
+		}()
+		logger.Info("serving metrics", "addr", metricsAddr, "path", "/metrics", "interval", watchInterval, "sinks", len(sinks))
+
+		var watchers []*watcher
+		for _, user := range users {
+			watchers = append(watchers, &watcher{
+				user:  user,
+				opts:  fetchOptions{FilterTypes: filter, PerPage: 100},
+				sinks: sinks,
+			})
+		}
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+		for {
+			for _, w := range watchers {
+				w.poll(ctx)
+			}
+			select {
+			case <-ctx.Done():
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				return srv.Shutdown(shutdownCtx)
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Minute, "How often to poll each user's events")
+	watchCmd.Flags().StringVar(&metricsAddr, "listen", ":9090", "Address to serve Prometheus metrics on")
+	watchCmd.Flags().StringSliceVarP(&filterTypes, "filter", "f", nil, "Comma-separated list of event types or aliases to watch")
+	watchCmd.Flags().StringVar(&accountName, "account", "", "Named account from the config file to use")
+	watchCmd.Flags().StringVarP(&githubToken, "api", "t", "", "Github API Token")
+	watchCmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
+	watchCmd.RegisterFlagCompletionFunc("filter", completeFilter)
+	watchCmd.RegisterFlagCompletionFunc("account", completeAccount)
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wizardAccount is the account name the setup wizard stores the token under
+const wizardAccount = "github"
+
+const (
+	wizardUsers = iota
+	wizardToken
+	wizardFilter
+	wizardTheme
+)
+
+var wizardPrompts = []string{
+	"GitHub username(s) to track (comma-separated)",
+	"GitHub API token (leave blank to use $GITHUB_TOKEN)",
+	"Default event filters, e.g. push,pr,social (leave blank for all events)",
+	"Theme",
+}
+
+// wizardModel is the first-run setup wizard that writes the config file
+type wizardModel struct {
+	step   int
+	inputs []textinput.Model
+	themes []string
+	theme  int
+	err    error
+	conf   *Config
+}
+
+func initialWizardModel() wizardModel {
+	users := textinput.New()
+	users.Placeholder = "blacktop, torvalds"
+	users.Focus()
+
+	token := textinput.New()
+	token.Placeholder = "ghp_... or ${GITHUB_TOKEN_WORK}"
+	token.EchoMode = textinput.EchoPassword
+	token.EchoCharacter = '•'
+
+	filter := textinput.New()
+	filter.Placeholder = "push,pr,issue"
+
+	return wizardModel{
+		inputs: []textinput.Model{users, token, filter},
+		themes: themeNames(),
+	}
+}
+
+func (m wizardModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m wizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			m.conf = nil
+			return m, tea.Quit
+		case "esc":
+			if m.step > wizardUsers {
+				m.err = nil
+				return m, m.setStep(m.step - 1)
+			}
+			return m, tea.Quit
+		case "enter":
+			if err := m.validate(); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			if m.step == wizardTheme {
+				m.conf = m.config()
+				return m, tea.Quit
+			}
+			return m, m.setStep(m.step + 1)
+		}
+		if m.step == wizardTheme {
+			switch msg.String() {
+			case "up", "k":
+				m.theme = (m.theme - 1 + len(m.themes)) % len(m.themes)
+			case "down", "j", "tab":
+				m.theme = (m.theme + 1) % len(m.themes)
+			}
+			// Preview the theme as it's selected
+			setTheme(m.themes[m.theme])
+			return m, nil
+		}
+	}
+	if m.step == wizardTheme {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inputs[m.step], cmd = m.inputs[m.step].Update(msg)
+	return m, cmd
+}
+
+func (m *wizardModel) setStep(step int) tea.Cmd {
+	if m.step < len(m.inputs) {
+		m.inputs[m.step].Blur()
+	}
+	m.step = step
+	if m.step < len(m.inputs) {
+		return m.inputs[m.step].Focus()
+	}
+	return nil
+}
+
+func (m wizardModel) validate() error {
+	switch m.step {
+	case wizardUsers:
+		if len(m.usernames()) == 0 {
+			return fmt.Errorf("at least one username is required")
+		}
+	case wizardFilter:
+		if _, err := parseFilterTypes(strings.Split(m.inputs[wizardFilter].Value(), ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m wizardModel) usernames() []string {
+	var names []string
+	for _, name := range strings.Split(m.inputs[wizardUsers].Value(), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// config builds the config from the wizard's answers
+func (m wizardModel) config() *Config {
+	conf := &Config{}
+	for _, name := range m.usernames() {
+		conf.Users = append(conf.Users, User{Name: name})
+	}
+	if token := strings.TrimSpace(m.inputs[wizardToken].Value()); token != "" {
+		conf.Accounts = map[string]Account{wizardAccount: {Token: token}}
+		conf.DefaultAccount = wizardAccount
+	}
+	for _, f := range strings.Split(m.inputs[wizardFilter].Value(), ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			conf.Filter = append(conf.Filter, f)
+		}
+	}
+	if theme := m.themes[m.theme]; theme != defaultThemeName {
+		conf.Theme = theme
+	}
+	return conf
+}
+
+func (m wizardModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.Accent).Render("Welcome to gitfamous!")
+	dim := lipgloss.NewStyle().Foreground(currentTheme.Dim)
+
+	var sb strings.Builder
+	sb.WriteString(title + " Let's set up your config.\n\n")
+	sb.WriteString(dim.Render(fmt.Sprintf("%d/%d ", m.step+1, len(wizardPrompts))) + wizardPrompts[m.step] + "\n\n")
+	if m.step == wizardTheme {
+		selected := lipgloss.NewStyle().Foreground(currentTheme.SelectedFg).Background(currentTheme.SelectedBg)
+		for i, name := range m.themes {
+			if i == m.theme {
+				sb.WriteString("> " + selected.Render(" "+name+" ") + "\n")
+			} else {
+				sb.WriteString("   " + name + "\n")
+			}
+		}
+	} else {
+		sb.WriteString(m.inputs[m.step].View() + "\n")
+	}
+	if m.err != nil {
+		sb.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("204")).Render(m.err.Error()) + "\n")
+	}
+	hint := "enter next • esc back • ctrl+c quit"
+	if m.step == wizardTheme {
+		hint = "↑/↓ choose • enter save • esc back • ctrl+c quit"
+	}
+	sb.WriteString("\n" + dim.Render(hint) + "\n")
+	return lipgloss.NewStyle().Padding(1, 2).Render(sb.String())
+}
+
+// runSetupWizard asks for the initial settings and writes them to the config file at path;
+// it returns false if the wizard was cancelled.
+func runSetupWizard(path string) (bool, error) {
+	detectBackground()
+	m, err := tea.NewProgram(initialWizardModel()).Run()
+	if err != nil {
+		return false, err
+	}
+	conf := m.(wizardModel).conf
+	if conf == nil {
+		return false, nil
+	}
+	if err := saveConfig(path, conf); err != nil {
+		return false, err
+	}
+	return true, nil
+}
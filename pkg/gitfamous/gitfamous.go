@@ -0,0 +1,89 @@
+// Package gitfamous embeds the gitfamous event fetcher and TUI in other programs.
+//
+//	events, err := gitfamous.Fetch(ctx,
+//		gitfamous.WithUser("blacktop"),
+//		gitfamous.WithToken(os.Getenv("GITHUB_TOKEN")),
+//		gitfamous.WithFilter("push", "pr"),
+//		gitfamous.WithCount(20),
+//	)
+package gitfamous
+
+import (
+	"context"
+	"time"
+
+	"github.com/blacktop/go-gitfamous/cmd"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Event is a GitHub event with its gitfamous description
+type Event = cmd.Event
+
+// Provider is a source of events, e.g. a recording or a mock in tests (the GitHub API by default)
+type Provider = cmd.Provider
+
+// Option configures Fetch, Run and Model
+type Option func(*cmd.Options)
+
+// WithUser sets the users to show (Fetch uses the first one)
+func WithUser(names ...string) Option {
+	return func(o *cmd.Options) { o.Users = append(o.Users, names...) }
+}
+
+// WithToken sets the GitHub API token
+func WithToken(token string) Option {
+	return func(o *cmd.Options) { o.Token = token }
+}
+
+// WithHost sets the GitHub Enterprise Server host
+func WithHost(host string) Option {
+	return func(o *cmd.Options) { o.Host = host }
+}
+
+// WithCount limits the number of events
+func WithCount(n int) Option {
+	return func(o *cmd.Options) { o.Count = n }
+}
+
+// WithFilter only keeps the given event types or aliases (push, pr, issue, code, social, ...)
+func WithFilter(types ...string) Option {
+	return func(o *cmd.Options) { o.Filter = append(o.Filter, types...) }
+}
+
+// WithIgnoreUsers hides the events of the given actors (bots, mirrors, ...)
+func WithIgnoreUsers(logins ...string) Option {
+	return func(o *cmd.Options) { o.Ignore = append(o.Ignore, logins...) }
+}
+
+// WithSince only keeps the events newer than d
+func WithSince(d time.Duration) Option {
+	return func(o *cmd.Options) { o.Since = d }
+}
+
+// WithProvider fetches events from p instead of the GitHub API
+func WithProvider(p Provider) Option {
+	return func(o *cmd.Options) { o.Provider = p }
+}
+
+func options(opts []Option) cmd.Options {
+	var o cmd.Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Fetch returns a user's events without starting the TUI
+func Fetch(ctx context.Context, opts ...Option) ([]Event, error) {
+	return cmd.Fetch(ctx, options(opts))
+}
+
+// Model returns the TUI as a bubbletea model to embed in another program
+func Model(opts ...Option) (tea.Model, error) {
+	return cmd.NewModel(options(opts))
+}
+
+// Run runs the TUI until it's quit or ctx is cancelled
+func Run(ctx context.Context, opts ...Option) error {
+	return cmd.Run(ctx, options(opts))
+}